@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+)
+
+// printEvent reports progress events from the document packages.
+func printEvent(e events.Event) {
+	switch e.Kind {
+	case events.FileWritten:
+		if e.Replaced {
+			fmt.Printf("Updated: %s\n", e.Path)
+		} else {
+			fmt.Printf("Created: %s\n", e.Path)
+		}
+	case events.FileRemoved:
+		fmt.Printf("Removed: %s\n", e.Path)
+	case events.FileConflict:
+		fmt.Fprintf(os.Stderr, "Conflict: %s was edited locally (use --force to overwrite)\n", e.Path)
+	case events.FileKept:
+		fmt.Printf("Kept: %s was edited locally and its section is unchanged\n", e.Path)
+	case events.SplitFinished:
+		fmt.Printf("Split %d oversized documents\n", e.Count)
+	case events.MergeFinished:
+		if e.Path == "-" {
+			fmt.Fprintf(os.Stderr, "Merged %d documents\n", e.Count)
+		} else {
+			fmt.Printf("Merged %d documents into %s\n", e.Count, e.Path)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/openapi"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// exportMarker starts every exported OpenAPI document, so that re-exports
+// may replace it without --force.
+const exportMarker = "# Generated by claude-docs export openapi. Edit the API docs under specs/ and re-export instead.\n"
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Generate structured specs from documentation",
+	Long:  "Generate structured specifications such as OpenAPI documents from Markdown documentation.",
+}
+
+var exportOpenAPICmd = &cobra.Command{
+	Use:   "openapi [directory]",
+	Short: "Generate an OpenAPI 3 document from the API docs",
+	Long: `Generate an OpenAPI 3 YAML document from the API documentation under specs/
+(specs/api.md, specs/api-spec.md and specs/api/).
+
+Each endpoint section is read for its HTTP method and URL, path and query
+parameters, request body example and success and error responses. Schemas
+are inferred from the JSON examples. Sections that cannot be parsed are
+reported as warnings and left out. The API version and server URL default
+to those listed in the API index, as written by import openapi.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		directory := "."
+		if len(args) > 0 {
+			directory = args[0]
+		}
+
+		outputFile, _ := cmd.Flags().GetString("output")
+		title, _ := cmd.Flags().GetString("title")
+		version, _ := cmd.Flags().GetString("api-version")
+		server, _ := cmd.Flags().GetString("server")
+
+		files := apispec.DocFiles(directory)
+		if len(files) == 0 {
+			checkError(fmt.Errorf("no API documentation found in %s (expected specs/api.md, specs/api-spec.md or specs/api/)", filepath.Join(directory, "specs")))
+		}
+
+		endpoints, warnings, err := apispec.ParseFiles(files)
+		checkError(err)
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		if len(endpoints) == 0 {
+			checkError(fmt.Errorf("no endpoints could be read from the API documentation"))
+		}
+
+		if title == "" {
+			title = documentedTitle(files[0])
+		}
+		if content, err := os.ReadFile(files[0]); err == nil {
+			info := apispec.ParseInfo(string(content))
+			if info.Version != "" && !cmd.Flags().Changed("api-version") {
+				version = info.Version
+			}
+			if info.BaseURL != "" && server == "" {
+				server = info.BaseURL
+			}
+		}
+		api := apispec.API{Title: title, Version: version, BaseURL: server, Endpoints: endpoints}
+
+		data, err := openapi.FromAPI(api).Marshal()
+		checkError(err)
+		data = append([]byte(exportMarker), data...)
+
+		if outputFile == "-" {
+			os.Stdout.Write(data)
+			return
+		}
+
+		out := newOutputWriter(cmd)
+		if existing, err := os.ReadFile(outputFile); err == nil && bytes.HasPrefix(existing, []byte(exportMarker)) {
+			owned := *out
+			owned.Force = true
+			out = &owned
+		}
+		checkError(out.MkdirAll(filepath.Dir(outputFile)))
+		action, err := out.WriteFile(outputFile, data, 0644)
+		checkError(err)
+		if out.DryRun {
+			return
+		}
+		if action != output.Unchanged {
+			fmt.Printf("Created: %s\n", outputFile)
+		}
+		fmt.Printf("Exported %d endpoints with %d warnings\n", len(endpoints), len(warnings))
+	},
+}
+
+func init() {
+	exportOpenAPICmd.Flags().String("output", "openapi.yaml", "OpenAPI file to write (- for stdout)")
+	exportOpenAPICmd.Flags().String("title", "", "API title (default: the heading of the API docs)")
+	exportOpenAPICmd.Flags().String("api-version", "1.0.0", "API version recorded in the document, unless the API docs list one")
+	exportOpenAPICmd.Flags().String("server", "", "Server URL recorded in the document (default: the base URL in the API docs)")
+	addOutputFlags(exportOpenAPICmd)
+	exportCmd.AddCommand(exportOpenAPICmd)
+}
+
+// documentedTitle returns the API title from the first heading of file,
+// without a trailing "Documentation".
+func documentedTitle(file string) string {
+	content, err := os.ReadFile(file)
+	if err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			if title, ok := strings.CutPrefix(line, "# "); ok {
+				title = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(title), " Documentation"))
+				if title != "" {
+					return title
+				}
+			}
+		}
+	}
+	return "API"
+}
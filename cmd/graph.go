@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/screens"
+	"github.com/spf13/cobra"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Generate diagrams from documentation",
+	Long:  "Generate diagrams such as the screen navigation graph from the specifications under specs/.",
+}
+
+var graphScreensCmd = &cobra.Command{
+	Use:   "screens [directory]",
+	Short: "Draw the navigation between screen specs",
+	Long: `Parse the screen specifications under specs/ (specs/screens.md, specs/screens/
+and any spec with Navigation, Entry Points or Exit Points sections) into a
+navigation graph and print it as a Mermaid flowchart or Graphviz DOT.
+
+Screens entered from outside the app ("From: App launch", "Deep link") or
+named with --root are entry screens. Unreachable screens, navigation to or
+from screens that are not specified, and navigation declared by only one of
+its two screens are reported on stderr.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		directory := "."
+		if len(args) > 0 {
+			directory = args[0]
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		outputFile, _ := cmd.Flags().GetString("output")
+		roots, _ := cmd.Flags().GetStringSlice("root")
+
+		specs, err := screens.ParseFiles(screens.DocFiles(directory))
+		checkError(err)
+		if len(specs) == 0 {
+			checkError(fmt.Errorf("no screen specifications found in %s", filepath.Join(directory, "specs")))
+		}
+
+		g := screens.Build(specs, roots)
+		var diagram string
+		switch format {
+		case "mermaid":
+			diagram = g.Mermaid()
+		case "dot":
+			diagram = g.DOT()
+		default:
+			checkError(fmt.Errorf("unknown format %q (use mermaid or dot)", format))
+		}
+
+		for _, issue := range g.Issues {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", issue)
+		}
+
+		if outputFile == "" || outputFile == "-" {
+			fmt.Print(diagram)
+			return
+		}
+
+		out := newOutputWriter(cmd)
+		checkError(out.MkdirAll(filepath.Dir(outputFile)))
+		action, err := out.WriteFile(outputFile, []byte(diagram), 0644)
+		checkError(err)
+		if !out.DryRun && action != output.Unchanged {
+			fmt.Printf("Created: %s\n", outputFile)
+		}
+	},
+}
+
+func init() {
+	graphScreensCmd.Flags().String("format", "mermaid", "Output format: mermaid or dot")
+	graphScreensCmd.Flags().String("output", "", "File to write the graph to (default: stdout)")
+	graphScreensCmd.Flags().StringSlice("root", nil, "Entry screens users start from (repeatable)")
+	addOutputFlags(graphScreensCmd)
+	graphCmd.AddCommand(graphScreensCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/openapi"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Generate documentation from structured specs",
+	Long:  "Generate Markdown documentation from structured specifications such as OpenAPI documents.",
+}
+
+var importOpenAPICmd = &cobra.Command{
+	Use:   "openapi <spec.yaml|spec.json>",
+	Short: "Generate API docs from an OpenAPI 3 document",
+	Long: `Generate API documentation from a local OpenAPI 3 document.
+
+Endpoints are written in the api template layout, grouped into one file per
+tag under --dir and split into parts above --max-size-kb, with an index
+linking every endpoint at --output. Re-importing replaces the generated
+files and removes those of tags that no longer exist.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		specFile := args[0]
+
+		indexFile, _ := cmd.Flags().GetString("output")
+		dir, _ := cmd.Flags().GetString("dir")
+		maxSizeKB, _ := cmd.Flags().GetInt64("max-size-kb")
+		baseURL, _ := cmd.Flags().GetString("base-url")
+
+		doc, err := openapi.Load(specFile)
+		checkError(err)
+
+		api := doc.API(filepath.ToSlash(specFile))
+		if baseURL != "" {
+			api.BaseURL = baseURL
+		}
+
+		g := apispec.New(indexFile, dir)
+		g.MaxSizeKB = maxSizeKB
+		g.Output = newOutputWriter(cmd)
+		g.OnEvent = printEvent
+
+		err = g.Generate(api)
+		checkError(err)
+	},
+}
+
+func init() {
+	importOpenAPICmd.Flags().String("output", "specs/api.md", "Index file linking every endpoint")
+	importOpenAPICmd.Flags().String("dir", "specs/api", "Directory for the per-tag endpoint files")
+	importOpenAPICmd.Flags().Int64("max-size-kb", 100, "Split tag files into parts above this size")
+	importOpenAPICmd.Flags().String("base-url", "", "Base URL for curl examples (default: the spec's first server)")
+	addOutputFlags(importOpenAPICmd)
+	importCmd.AddCommand(importOpenAPICmd)
+}
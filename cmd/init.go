@@ -2,24 +2,162 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/project"
+	"github.com/claude-code/claude-doc-structure/internal/upgrade"
 	"github.com/spf13/cobra"
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init [project-name]",
 	Short: "Initialize documentation structure",
-	Long:  "Initialize Claude documentation structure in current directory.",
+	Long: `Initialize Claude documentation structure in current directory.
+
+With -i, a short wizard asks for the project description, technology stack,
+commands, key directories and constraints, offering defaults detected from
+go.mod, package.json, pyproject.toml, Cargo.toml, the Makefile and the README,
+and fills CLAUDE.md and .claude/context.md with the answers. --answers reads
+the same answers from a YAML file for reproducible, non-interactive setup:
+
+  name: shop
+  description: Online shop backend
+  stack: [Go 1.22, PostgreSQL]
+  commands:
+    build: make build
+    test: go test ./...
+  directories:
+    cmd/: Command entry points
+  constraints:
+    - Must run without network access
+
+--profile picks the files to create. minimal creates only CLAUDE.md, for
+projects that keep everything in one file; standard adds the core .claude/
+files and the specs/api.md and specs/screens.md domain files; full, the
+default, creates every .claude/ file, as init always has; hierarchical adds
+the specs/api/, specs/screens/ and specs/features/ directories for
+hierarchical specifications. Profiles can be defined in .claude-docs.yaml:
+
+  default_profile: backend
+  profiles:
+    backend:
+      extends: minimal
+      files: [.claude/context.md, specs/api.md]
+      dirs: [specs/api]
+
+--only and --skip select files by path, file name, or name without .md.
+
+--target initializes another directory instead of the current one. Give it
+several times, or list directories in --targets-file, to bootstrap many
+repositories at once: each is named after its directory, reads its own
+.claude-docs.yaml, and a failure in one is reported without stopping the
+others.`,
 	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		projectName := getProjectName(args)
-		initProject(projectName)
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		answersFile, _ := cmd.Flags().GetString("answers")
+		saveAnswers, _ := cmd.Flags().GetString("save-answers")
+		profile, _ := cmd.Flags().GetString("profile")
+		only, _ := cmd.Flags().GetStringSlice("only")
+		skip, _ := cmd.Flags().GetStringSlice("skip")
+		targets, _ := cmd.Flags().GetStringSlice("target")
+		targetsFile, _ := cmd.Flags().GetString("targets-file")
+		out := newOutputWriter(cmd)
+		
+		if targetsFile != "" {
+			listed, err := readTargets(targetsFile)
+			checkError(err)
+			targets = append(targets, listed...)
+		}
+		if len(targets) == 0 {
+			targets = []string{"."}
+		}
+		bulk := len(targets) > 1
+		if bulk && len(args) > 0 {
+			checkError(fmt.Errorf("a project name can only be given for a single target"))
+		}
+		if bulk && interactive {
+			checkError(fmt.Errorf("interactive mode works on a single target; use --answers <file> instead"))
+		}
+		
+		var answers project.Answers
+		if answersFile != "" {
+			loaded, err := project.Load(answersFile)
+			checkError(err)
+			answers = loaded
+		}
+		if len(args) > 0 {
+			answers.Name = args[0]
+		}
+		
+		if interactive {
+			if !isTerminal(os.Stdin) {
+				checkError(fmt.Errorf("interactive mode needs a terminal; use --answers <file> instead"))
+			}
+			defaults := project.Detect(targets[0]).Merge(answers)
+			if defaults.Name == "" {
+				defaults.Name = projectNameOf(targets[0])
+			}
+			fmt.Println("Answer a few questions to fill in CLAUDE.md and .claude/context.md.")
+			fmt.Println("Press Enter to accept the [default], or enter - to leave a placeholder.")
+			asked, err := project.NewPrompter(os.Stdin, os.Stdout).Ask(defaults)
+			checkError(err)
+			answers = asked
+			fmt.Println()
+		}
+		
+		if !bulk {
+			if answers.Name == "" {
+				answers.Name = projectNameOf(targets[0])
+			}
+			files, _, err := initTarget(targets[0], answers, profile, only, skip, out)
+			checkError(err)
+			if !out.DryRun {
+				printNextSteps(files)
+			}
+		} else {
+			results, failed := initTargets(targets, answers, profile, only, skip, out)
+			fmt.Printf("Initialized %d of %d repositories:\n", len(targets)-failed, len(targets))
+			for _, result := range results {
+				fmt.Println(result)
+			}
+			if failed > 0 {
+				os.Exit(1)
+			}
+		}
+		
+		if saveAnswers != "" {
+			data, err := answers.Marshal()
+			checkError(err)
+			writeInitFile(out, saveAnswers, string(data))
+		}
 	},
 }
 
+func init() {
+	initCmd.Flags().BoolP("interactive", "i", false, "Ask for the project description, stack, commands, directories and constraints")
+	initCmd.Flags().String("answers", "", "YAML file with the answers to fill in, for non-interactive setup")
+	initCmd.Flags().String("save-answers", "", "Write the answers to a YAML file for later --answers runs")
+	initCmd.Flags().String("profile", "", "Files to create: minimal, standard, full, hierarchical or a profile from .claude-docs.yaml (default full)")
+	initCmd.Flags().StringSlice("only", nil, "Create only these files, e.g. CLAUDE.md,context (replaces the profile)")
+	initCmd.Flags().StringSlice("skip", nil, "Files not to create, e.g. specs/screens.md")
+	initCmd.Flags().StringSlice("target", nil, "Directory to initialize instead of the current one (repeatable for bulk mode)")
+	initCmd.Flags().String("targets-file", "", "File listing directories to initialize, one per line (- for stdin)")
+	initCmd.MarkFlagsMutuallyExclusive("profile", "only")
+	addOutputFlags(initCmd)
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 func readFromTemplateFile(templatePath, projectName string) string {
 	content, err := os.ReadFile(templatePath)
 	if err != nil {
@@ -29,79 +167,358 @@ func readFromTemplateFile(templatePath, projectName string) string {
 	return strings.ReplaceAll(string(content), "{name}", projectName)
 }
 
-func getProjectName(args []string) string {
-	if len(args) > 0 {
-		return args[0]
-	}
-	
-	cwd, err := os.Getwd()
+// projectNameOf returns the name of the directory dir.
+func projectNameOf(dir string) string {
+	abs, err := filepath.Abs(dir)
 	if err != nil {
 		return "project"
 	}
+	return filepath.Base(abs)
+}
+
+// readTargets reads the directories listed in path, one per line. Blank
+// lines and lines starting with # are ignored.
+func readTargets(path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read targets file: %w", err)
+	}
 	
-	return filepath.Base(cwd)
+	var targets []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			targets = append(targets, line)
+		}
+	}
+	return targets, nil
+}
+
+// initResult counts the files init created and the existing ones it kept.
+type initResult struct {
+	Created int
+	Skipped int
+}
+
+// initTarget initializes the project in dir with the files selected by its
+// own .claude-docs.yaml and returns the selected files.
+func initTarget(dir string, answers project.Answers, profile string, only, skip []string, out *output.Writer) ([]string, initResult, error) {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, initResult{}, fmt.Errorf("not a directory: %s", dir)
+	}
+	config, err := project.LoadConfig(dir)
+	if err != nil {
+		return nil, initResult{}, err
+	}
+	files, dirs, err := selectInitFiles(config, profile, only, skip)
+	if err != nil {
+		return nil, initResult{}, err
+	}
+	result, err := initProject(dir, answers.Name, answers, files, dirs, out)
+	return files, result, err
+}
+
+// initTargets initializes each of the targets and returns a result line
+// per repository and the number that failed. Each repository is named
+// after its directory and a failure does not stop the others.
+func initTargets(targets []string, answers project.Answers, profile string, only, skip []string, out *output.Writer) ([]string, int) {
+	var results []string
+	failed := 0
+	for _, target := range targets {
+		repoAnswers := answers
+		repoAnswers.Name = projectNameOf(target)
+		_, result, err := initTarget(target, repoAnswers, profile, only, skip, out)
+		if err != nil {
+			failed++
+			fmt.Printf("Error: %v\n", err)
+			results = append(results, fmt.Sprintf("  failed  %s: %v", target, err))
+		} else {
+			results = append(results, fmt.Sprintf("  ok      %s (%d created, %d skipped)", target, result.Created, result.Skipped))
+		}
+		fmt.Println()
+	}
+	return results, failed
 }
 
-func initProject(projectName string) {
-	fmt.Printf("Initializing Claude documentation structure for '%s'...\n", projectName)
+// templateVersion identifies the built-in templates. Increase it whenever
+// they change so that upgrade can tell projects what they are behind.
+const templateVersion = 1
+
+// initFiles lists the files init can create, in the order they are created.
+var initFiles = []string{
+	"CLAUDE.md",
+	".claude/context.md",
+	".claude/project-knowledge.md",
+	".claude/project-improvements.md",
+	".claude/common-patterns.md",
+	".claude/debug-log.md",
+	".claude/project-specifics.md",
+	".claude/ai-collaboration.md",
+	".claude/code-patterns.md",
+	"specs/api.md",
+	"specs/screens.md",
+}
+
+// selectInitFiles returns the files and directories init creates: those of
+// the profile, or the files named by only, less the files named by skip.
+func selectInitFiles(config project.Config, profile string, only, skip []string) ([]string, []string, error) {
+	for _, selector := range append(append([]string{}, only...), skip...) {
+		if !isInitFile(selector) {
+			return nil, nil, fmt.Errorf("unknown file %q (available: %s)", selector, strings.Join(initFiles, ", "))
+		}
+	}
 	
-	// Create enhanced structure with .claude/ optimization
-	directories := []string{
-		"specs",
-		".claude",
+	var selected, dirs []string
+	if len(only) > 0 {
+		selected = only
+	} else {
+		var err error
+		if selected, dirs, err = config.Resolve(profile); err != nil {
+			return nil, nil, err
+		}
+		for _, file := range selected {
+			if !isInitFile(file) {
+				return nil, nil, fmt.Errorf("profile lists unknown file %q (available: %s)", file, strings.Join(initFiles, ", "))
+			}
+		}
+	}
+	
+	var files []string
+	for _, file := range initFiles {
+		if matchesAny(selected, file) && !matchesAny(skip, file) {
+			files = append(files, file)
+		}
+	}
+	return files, dirs, nil
+}
+
+// isInitFile reports whether selector names one of the init files.
+func isInitFile(selector string) bool {
+	for _, file := range initFiles {
+		if project.MatchFile(file, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether any of the selectors names file.
+func matchesAny(selectors []string, file string) bool {
+	for _, selector := range selectors {
+		if project.MatchFile(file, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// initProject creates the files and directories in dir.
+func initProject(dir, projectName string, answers project.Answers, files, dirs []string, out *output.Writer) (initResult, error) {
+	var result initResult
+	generated := make(map[string]string)
+	if dir == "." {
+		fmt.Printf("Initializing Claude documentation structure for '%s'...\n", projectName)
+	} else {
+		fmt.Printf("Initializing Claude documentation structure for '%s' in %s...\n", projectName, dir)
 	}
 	
-	for _, dir := range directories {
-		err := os.MkdirAll(dir, 0755)
-		checkError(err)
-		fmt.Printf("Created directory: %s\n", dir)
+	// Create the directories of the selected files and the profile's hierarchy
+	var directories []string
+	for _, file := range files {
+		if parent := filepath.Dir(file); parent != "." {
+			directories = appendUnique(directories, parent)
+		}
+	}
+	for _, d := range dirs {
+		directories = appendUnique(directories, d)
 	}
 	
-	// Load and customize CLAUDE.md template
-	claudeMdContent := loadTemplate("CLAUDE.md", projectName)
+	for _, d := range directories {
+		path := filepath.Join(dir, d)
+		if err := out.MkdirAll(path); err != nil {
+			return result, err
+		}
+		if !out.DryRun {
+			fmt.Printf("Created directory: %s\n", path)
+		}
+	}
 	
-	claudeMdPath := "CLAUDE.md"
-	if _, err := os.Stat(claudeMdPath); os.IsNotExist(err) {
-		err := os.WriteFile(claudeMdPath, []byte(claudeMdContent), 0644)
-		checkError(err)
-		fmt.Printf("Created: %s\n", claudeMdPath)
-	} else {
-		fmt.Println("CLAUDE.md already exists, skipping...")
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err == nil && !out.Force {
+			fmt.Printf("%s already exists, skipping...\n", path)
+			result.Skipped++
+			continue
+		}
+		content := renderInitFile(dir, file, projectName, answers, files)
+		action, err := out.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			return result, err
+		}
+		if action != output.Unchanged {
+			result.Created++
+			if !out.DryRun {
+				fmt.Printf("Created: %s\n", path)
+			}
+		}
+		generated[file] = content
 	}
 	
-	// Create all templates with .claude/ structure
-	templateFiles := map[string]string{
-		"specs/api.md":                    loadTemplate("specs/api.md", projectName),
-		"specs/screens.md":                loadTemplate("specs/screens.md", projectName),
-		".claude/context.md":              loadTemplate(".claude/context.md", projectName),
-		".claude/project-knowledge.md":    loadTemplate(".claude/project-knowledge.md", projectName),
-		".claude/project-improvements.md": loadTemplate(".claude/project-improvements.md", projectName),
-		".claude/common-patterns.md":      loadTemplate(".claude/common-patterns.md", projectName),
-		".claude/debug-log.md":            loadTemplate(".claude/debug-log.md", projectName),
-		".claude/project-specifics.md":    loadTemplate(".claude/project-specifics.md", projectName),
-		".claude/ai-collaboration.md":     loadTemplate(".claude/ai-collaboration.md", projectName),
-		".claude/code-patterns.md":        loadTemplate(".claude/code-patterns.md", projectName),
+	if len(generated) > 0 {
+		if err := recordTemplates(dir, answers, generated, out); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// renderInitFile returns the content init writes to file.
+func renderInitFile(dir, file, projectName string, answers project.Answers, files []string) string {
+	content := project.Fill(file, loadTemplate(file, projectName), answers)
+	if file == "CLAUDE.md" {
+		content = pruneContextLinks(dir, content, files)
+	}
+	return content
+}
+
+// recordTemplates adds the generated files to the lock file in dir, as the
+// base that upgrade merges template changes against.
+func recordTemplates(dir string, answers project.Answers, generated map[string]string, out *output.Writer) error {
+	lock, err := upgrade.LoadLock(dir)
+	if err != nil {
+		return err
+	}
+	if lock == nil {
+		lock = &upgrade.Lock{Files: make(map[string]string)}
+	}
+	lock.Version = templateVersion
+	lock.Answers = answers
+	for file, content := range generated {
+		lock.Files[file] = content
 	}
 	
-	for filePath, content := range templateFiles {
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			err := os.WriteFile(filePath, []byte(content), 0644)
-			checkError(err)
-			fmt.Printf("Created: %s\n", filePath)
+	data, err := lock.Marshal()
+	if err != nil {
+		return err
+	}
+	owned := *out
+	owned.Force = true
+	_, err = owned.WriteFile(filepath.Join(dir, upgrade.LockFile), data, 0644)
+	return err
+}
+
+// printNextSteps suggests what to fill in first among the created files.
+func printNextSteps(files []string) {
+	selected := func(file string) bool { return matchesAny(files, file) }
+	var steps []string
+	if selected("CLAUDE.md") {
+		steps = append(steps, "Edit CLAUDE.md with your project details")
+	}
+	if selected(".claude/context.md") {
+		steps = append(steps, "Update .claude/context.md with project background and constraints")
+	}
+	if selected(".claude/project-knowledge.md") {
+		steps = append(steps, "Fill in .claude/project-knowledge.md with technical insights")
+	}
+	var specs []string
+	for _, file := range files {
+		if strings.HasPrefix(file, "specs/") {
+			specs = append(specs, file)
 		}
 	}
+	if len(specs) > 0 {
+		steps = append(steps, "Update "+strings.Join(specs, " and "))
+	}
+	steps = append(steps, "Start collaborating with Claude Code for enhanced AI assistance!")
 	
 	fmt.Println("\nClaude-optimized documentation structure initialized successfully!")
 	fmt.Println("Next steps:")
-	fmt.Println("1. Edit CLAUDE.md with your project details")
-	fmt.Println("2. Update .claude/context.md with project background and constraints")
-	fmt.Println("3. Fill in .claude/project-knowledge.md with technical insights")
-	fmt.Println("4. Update specs/api.md and specs/screens.md")
-	fmt.Println("5. Start collaborating with Claude Code for enhanced AI assistance!")
+	for i, step := range steps {
+		fmt.Printf("%d. %s\n", i+1, step)
+	}
 	fmt.Println("\nThis structure follows best practices from:")
 	fmt.Println("https://zenn.dev/driller/articles/2a23ef94f1d603")
 }
 
+var orderedItem = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+
+// pruneContextLinks removes the entries of CLAUDE.md that name .claude
+// files which are neither created nor present: the Quick Context Access
+// links, dropping the section when none remain, and the Documentation
+// Maintenance steps, which are renumbered.
+func pruneContextLinks(dir, content string, files []string) string {
+	missing := func(line string) bool {
+		i := strings.Index(line, "`.claude/")
+		if i < 0 {
+			return false
+		}
+		file := strings.SplitN(line[i+1:], "`", 2)[0]
+		_, err := os.Stat(filepath.Join(dir, file))
+		return err != nil && !matchesAny(files, file)
+	}
+
+	var kept []string
+	section := ""
+	start, entries, steps := -1, 0, 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			if start >= 0 && entries == 0 {
+				kept = kept[:start]
+			}
+			section = strings.TrimSpace(line)
+			start, entries, steps = -1, 0, 0
+			if section == "## Quick Context Access" {
+				start = len(kept)
+			}
+		}
+		switch section {
+		case "## Quick Context Access":
+			if strings.HasPrefix(line, "- `.claude/") {
+				if missing(line) {
+					continue
+				}
+				entries++
+			}
+		case "## Documentation Maintenance":
+			if matches := orderedItem.FindStringSubmatch(line); matches != nil {
+				if missing(line) {
+					continue
+				}
+				steps++
+				line = fmt.Sprintf("%d. %s", steps, matches[1])
+			}
+		}
+		kept = append(kept, line)
+	}
+	if start >= 0 && entries == 0 {
+		kept = kept[:start]
+	}
+	return strings.Join(kept, "\n")
+}
+
+func appendUnique(list []string, item string) []string {
+	for _, existing := range list {
+		if existing == item {
+			return list
+		}
+	}
+	return append(list, item)
+}
+
+func writeInitFile(out *output.Writer, filePath, content string) {
+	action, err := out.WriteFile(filePath, []byte(content), 0644)
+	checkError(err)
+	if !out.DryRun && action != output.Unchanged {
+		fmt.Printf("Created: %s\n", filePath)
+	}
+}
+
 func loadTemplate(templatePath, projectName string) string {
 	// For now, use built-in templates
 	// In the future, this could read from external files or embedded templates
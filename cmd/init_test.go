@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/project"
+)
+
+func TestPruneContextLinks(t *testing.T) {
+	content := renderInitFile(t.TempDir(), "CLAUDE.md", "demo", project.Answers{}, initFiles)
+
+	tests := []struct {
+		name     string
+		files    []string
+		existing []string // .claude files already in the directory
+		// want lists the .claude files linked from each section
+		wantContext     []string
+		wantMaintenance []string
+	}{
+		{
+			name:            "claude.md only",
+			files:           []string{"CLAUDE.md"},
+			wantMaintenance: []string{"this CLAUDE.md"},
+		},
+		{
+			name:            "selected files",
+			files:           []string{"CLAUDE.md", ".claude/debug-log.md", ".claude/common-patterns.md"},
+			wantContext:     []string{".claude/common-patterns.md", ".claude/debug-log.md"},
+			wantMaintenance: []string{".claude/debug-log.md", ".claude/common-patterns.md", "this CLAUDE.md"},
+		},
+		{
+			name:            "files already present count",
+			files:           []string{"CLAUDE.md"},
+			existing:        []string{".claude/project-knowledge.md"},
+			wantContext:     []string{".claude/project-knowledge.md"},
+			wantMaintenance: []string{".claude/project-knowledge.md", "this CLAUDE.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tt.existing {
+				path := filepath.Join(dir, file)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("# existing\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			pruned := pruneContextLinks(dir, content, tt.files)
+			var context, maintenance []string
+			section := ""
+			for _, line := range strings.Split(pruned, "\n") {
+				if strings.HasPrefix(line, "## ") {
+					section = line
+				}
+				switch {
+				case section == "## Quick Context Access" && strings.HasPrefix(line, "- `"):
+					context = append(context, strings.SplitN(line[3:], "`", 2)[0])
+				case section == "## Documentation Maintenance" && orderedItem.MatchString(line):
+					// Steps are numbered from 1 without gaps
+					if want := len(maintenance) + 1; !strings.HasPrefix(line, fmt.Sprintf("%d. ", want)) {
+						t.Errorf("step %q is not numbered %d", line, want)
+					}
+					if i := strings.Index(line, "`"); i >= 0 {
+						maintenance = append(maintenance, strings.SplitN(line[i+1:], "`", 2)[0])
+					} else if strings.Contains(line, "this CLAUDE.md") {
+						maintenance = append(maintenance, "this CLAUDE.md")
+					}
+				}
+			}
+
+			if strings.Join(context, ",") != strings.Join(tt.wantContext, ",") {
+				t.Errorf("Quick Context Access = %v, want %v", context, tt.wantContext)
+			}
+			if len(tt.wantContext) == 0 && strings.Contains(pruned, "## Quick Context Access") {
+				t.Errorf("empty Quick Context Access section was kept")
+			}
+			if strings.Join(maintenance, ",") != strings.Join(tt.wantMaintenance, ",") {
+				t.Errorf("Documentation Maintenance = %v, want %v", maintenance, tt.wantMaintenance)
+			}
+		})
+	}
+}
+
+func TestReadTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(path, []byte("# repositories\nrepos/shop\n\n  repos/web  \n#repos/old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	targets, err := readTargets(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(targets, ","); got != "repos/shop,repos/web" {
+		t.Errorf("targets = %s, want repos/shop,repos/web", got)
+	}
+
+	if _, err := readTargets(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("reading a missing targets file succeeded")
+	}
+}
+
+func TestInitTargets(t *testing.T) {
+	root := t.TempDir()
+	repo := func(name string, files map[string]string) string {
+		dir := filepath.Join(root, name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for file, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+
+	tests := []struct {
+		name        string
+		dir         string
+		wantResult  string
+		wantCreated []string
+	}{
+		{
+			name:        "new repository",
+			dir:         repo("shop", nil),
+			wantResult:  "ok      %s (11 created, 0 skipped)",
+			wantCreated: []string{"CLAUDE.md", ".claude/context.md"},
+		},
+		{
+			name:       "existing files are kept",
+			dir:        repo("web", map[string]string{"CLAUDE.md": "# Web\n"}),
+			wantResult: "ok      %s (10 created, 1 skipped)",
+		},
+		{
+			name:        "own profile",
+			dir:         repo("api", map[string]string{project.ConfigFile: "default_profile: api\nprofiles:\n  api:\n    files: [specs/api.md]\n"}),
+			wantResult:  "ok      %s (1 created, 0 skipped)",
+			wantCreated: []string{"specs/api.md"},
+		},
+		{
+			name:       "invalid configuration",
+			dir:        repo("broken", map[string]string{project.ConfigFile: "profiles: [\n"}),
+			wantResult: "failed  %s: failed to parse",
+		},
+		{
+			name:       "missing directory",
+			dir:        filepath.Join(root, "missing"),
+			wantResult: "failed  %s: not a directory",
+		},
+	}
+
+	var targets []string
+	for _, tt := range tests {
+		targets = append(targets, tt.dir)
+	}
+	out := &output.Writer{Log: io.Discard}
+	results, failed := initTargets(targets, project.Answers{}, "", nil, nil, out)
+	if failed != 2 {
+		t.Errorf("%d failed, want 2", failed)
+	}
+	if len(results) != len(tests) {
+		t.Fatalf("%d results for %d targets", len(results), len(tests))
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if want := "  " + fmt.Sprintf(tt.wantResult, tt.dir); !strings.HasPrefix(results[i], want) {
+				t.Errorf("result = %q, want it to start with %q", results[i], want)
+			}
+			for _, file := range tt.wantCreated {
+				content, err := os.ReadFile(filepath.Join(tt.dir, file))
+				if err != nil {
+					t.Errorf("%s was not created", file)
+				} else if file == "CLAUDE.md" && !strings.Contains(string(content), filepath.Base(tt.dir)) {
+					t.Errorf("CLAUDE.md is not named after %s", filepath.Base(tt.dir))
+				}
+			}
+		})
+	}
+}
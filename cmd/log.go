@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/claude-code/claude-doc-structure/internal/devlog"
+	"github.com/spf13/cobra"
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Add dated entries to the debug log and improvement history",
+	Long: `Append correctly formatted, dated entries to .claude/debug-log.md and
+.claude/project-improvements.md. Entries are kept sorted newest first, the
+template's example entry is dropped, and with --keep or --max-age older
+entries are moved to an archive file (.claude/archive/ by default).
+
+Fields come from flags, or when no field flags are given, from standard
+input as "Label: value" lines:
+
+  echo "Problem: Login fails after token refresh
+  Root Cause: Stale token cached in the client
+  Solution: Invalidate the cache on refresh" | claude-docs log debug "Login loop"`,
+}
+
+var logDebugCmd = &cobra.Command{
+	Use:   "debug [title]",
+	Short: "Record a resolved issue in .claude/debug-log.md",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLog(cmd, args, devlog.Debug, map[string]string{
+			"Problem":    "problem",
+			"Root Cause": "cause",
+			"Solution":   "solution",
+			"Prevention": "prevention",
+		})
+	},
+}
+
+var logImprovementCmd = &cobra.Command{
+	Use:   "improvement [title]",
+	Short: "Record a milestone in .claude/project-improvements.md",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLog(cmd, args, devlog.Improvements, map[string]string{
+			"Achievement": "summary",
+			"Impact":      "impact",
+		})
+	},
+}
+
+func init() {
+	logDebugCmd.Flags().String("problem", "", "What went wrong")
+	logDebugCmd.Flags().String("cause", "", "The root cause")
+	logDebugCmd.Flags().String("solution", "", "How it was resolved")
+	logDebugCmd.Flags().String("prevention", "", "How it is prevented from recurring")
+
+	logImprovementCmd.Flags().String("summary", "", "What was achieved")
+	logImprovementCmd.Flags().StringSlice("change", nil, "A change made (repeatable)")
+	logImprovementCmd.Flags().String("impact", "", "The impact of the improvement")
+
+	for _, cmd := range []*cobra.Command{logDebugCmd, logImprovementCmd} {
+		cmd.Flags().StringSlice("files", nil, "Files touched")
+		cmd.Flags().String("commit", "", "Commit of the change, as any git revision")
+		cmd.Flags().Bool("head", false, "Record the current HEAD commit")
+		cmd.MarkFlagsMutuallyExclusive("commit", "head")
+		cmd.Flags().String("date", "", "Date of the entry, YYYY-MM-DD (default today)")
+		cmd.Flags().String("file", "", "Log file (default the project's log)")
+		cmd.Flags().Int("keep", 0, "Archive all but the newest N entries")
+		cmd.Flags().Int("max-age", 0, "Archive entries older than this many days")
+		cmd.Flags().String("archive", "", "Archive file (default .claude/archive/<log file name>)")
+		cmd.Flags().Bool("dry-run", false, "Print planned file changes without writing them")
+		logCmd.AddCommand(cmd)
+	}
+}
+
+// runLog adds an entry to log, reading the fields from the flags named in
+// flags, or from standard input when none of them is set.
+func runLog(cmd *cobra.Command, args []string, log devlog.Log, flags map[string]string) {
+	file, _ := cmd.Flags().GetString("file")
+	date, _ := cmd.Flags().GetString("date")
+	keep, _ := cmd.Flags().GetInt("keep")
+	maxAge, _ := cmd.Flags().GetInt("max-age")
+	archive, _ := cmd.Flags().GetString("archive")
+	if file == "" {
+		file = log.File
+	}
+	if archive == "" {
+		archive = filepath.Join(filepath.Dir(file), "archive", filepath.Base(file))
+	}
+	if date == "" {
+		date = time.Now().Format(devlog.DateFormat)
+	}
+
+	entry := devlog.Entry{Date: date, Fields: make(map[string]string)}
+	if len(args) > 0 {
+		entry.Title = args[0]
+	}
+
+	fromFlags := false
+	for label, name := range flags {
+		if value, _ := cmd.Flags().GetString(name); value != "" {
+			entry.Fields[label] = value
+			fromFlags = true
+		}
+	}
+	if cmd.Flags().Lookup("change") != nil {
+		entry.Items, _ = cmd.Flags().GetStringSlice("change")
+		fromFlags = fromFlags || len(entry.Items) > 0
+	}
+	if !fromFlags {
+		if isTerminal(os.Stdin) {
+			checkError(fmt.Errorf("no entry fields given; use the flags or pipe \"Label: value\" lines on stdin"))
+		}
+		devlog.ParseFields(sectionInput(cmd), log, &entry)
+		if len(args) > 0 {
+			entry.Title = args[0]
+		}
+		if len(entry.Fields) == 0 && len(entry.Items) == 0 {
+			checkError(fmt.Errorf("no entry fields found on stdin; expected \"Label: value\" lines such as \"%s: ...\"", log.Labels[0]))
+		}
+	}
+
+	if files, _ := cmd.Flags().GetStringSlice("files"); len(files) > 0 {
+		quoted := make([]string, len(files))
+		for i, f := range files {
+			quoted[i] = "`" + filepath.ToSlash(f) + "`"
+		}
+		entry.Fields["Files"] = strings.Join(quoted, ", ")
+	}
+	commit, _ := cmd.Flags().GetString("commit")
+	if head, _ := cmd.Flags().GetBool("head"); head {
+		commit = "HEAD"
+	}
+	if commit != "" {
+		hash, err := resolveCommit(commit)
+		checkError(err)
+		entry.Fields["Commit"] = "`" + hash + "`"
+	}
+	checkError(entry.Validate())
+
+	var content string
+	if data, err := os.ReadFile(file); err == nil {
+		content = string(data)
+	} else if os.IsNotExist(err) {
+		content = loadTemplate(log.File, projectNameOf("."))
+	} else {
+		checkError(fmt.Errorf("failed to read %s: %w", file, err))
+	}
+
+	content, err := devlog.Add(content, log, entry)
+	checkError(err)
+
+	var archived []devlog.Entry
+	if keep > 0 || maxAge > 0 {
+		cutoff := ""
+		if maxAge > 0 {
+			cutoff = time.Now().AddDate(0, 0, -maxAge).Format(devlog.DateFormat)
+		}
+		content, archived, err = devlog.Rotate(content, log, keep, cutoff)
+		checkError(err)
+	}
+
+	// The logs are meant to be edited by this command
+	out := newOutputWriter(cmd)
+	out.Force = true
+
+	// Archived entries are written, and synced, before they leave the
+	// log, so that a failure in between never loses them
+	if len(archived) > 0 {
+		existing, err := os.ReadFile(archive)
+		if err != nil && !os.IsNotExist(err) {
+			checkError(fmt.Errorf("failed to read %s: %w", archive, err))
+		}
+		updated, err := devlog.Archive(string(existing), log, archived)
+		checkError(err)
+		_, err = out.WriteFile(archive, []byte(updated), 0644)
+		checkError(err)
+	}
+
+	_, err = out.WriteFile(file, []byte(content), 0644)
+	checkError(err)
+
+	if out.DryRun {
+		return
+	}
+	fmt.Printf("Added: %s: %s to %s\n", entry.Date, entry.Title, file)
+	if len(archived) > 0 {
+		fmt.Printf("Archived: %d entries to %s\n", len(archived), archive)
+	}
+}
+
+// resolveCommit returns the short hash of a git revision. Values git does
+// not know are kept as given, except HEAD, which must resolve.
+func resolveCommit(rev string) (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--short", rev).Output()
+	if err != nil {
+		if rev == "HEAD" {
+			return "", fmt.Errorf("failed to resolve HEAD: not in a git repository with commits")
+		}
+		return rev, nil
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -1,7 +1,12 @@
 package cmd
 
 import (
-	"github.com/claude-code/claude-doc-structure/internal/merger"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/claude-code/claude-doc-structure/internal/watcher"
+	"github.com/claude-code/claude-doc-structure/pkg/claudedocs"
 	"github.com/spf13/cobra"
 )
 
@@ -22,19 +27,71 @@ var mergeCmd = &cobra.Command{
 		noStructure, _ := cmd.Flags().GetBool("no-structure")
 		noSummary, _ := cmd.Flags().GetBool("no-summary")
 		noClaudeOptimization, _ := cmd.Flags().GetBool("no-claude-optimization")
+		cacheDir, _ := cmd.Flags().GetString("cache-dir")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		jobs, _ := cmd.Flags().GetInt("jobs")
+		check, _ := cmd.Flags().GetBool("check")
+		watch, _ := cmd.Flags().GetBool("watch")
+		poll, _ := cmd.Flags().GetBool("poll")
+		
+		dirOpts := claudedocs.DirOptions{
+			Pattern:   pattern,
+			Exclude:   exclude,
+			Recursive: recursive,
+			Skip:      []string{output, cacheDir},
+		}
+		
+		opts := claudedocs.DefaultMergeOptions()
+		opts.SourceDir = inputDir
+		opts.AddTOC = !noTOC
+		opts.AddDividers = !noDividers
+		opts.PreserveStructure = !noStructure
+		opts.AddSummary = !noSummary
+		opts.OptimizeForClaude = !noClaudeOptimization
+		opts.Jobs = jobs
+		opts.OnEvent = printEvent
+		write := writeOptions(cmd)
+		// Only runs that write the output file update the cache
+		if !noCache && !write.DryRun && !check && output != "-" {
+			opts.CacheDir = cacheDir
+		}
+		
+		if check {
+			sources, err := claudedocs.DirSources(inputDir, dirOpts)
+			checkError(err)
+			upToDate, err := claudedocs.Check(cmd.Context(), sources, opts, output)
+			checkError(err)
+			if !upToDate {
+				fmt.Fprintf(os.Stderr, "%s is out of date; run 'claude-docs merge %s' to regenerate it\n", output, inputDir)
+				os.Exit(1)
+			}
+			fmt.Printf("%s is up to date\n", output)
+			return
+		}
+		
+		run := func() error {
+			sources, err := claudedocs.DirSources(inputDir, dirOpts)
+			if err != nil {
+				return err
+			}
+			if output == "-" {
+				return claudedocs.Merge(cmd.Context(), sources, opts, os.Stdout)
+			}
+			return claudedocs.MergeFile(cmd.Context(), sources, opts, output, write)
+		}
 		
-		// Create merger
-		m := merger.New(inputDir, output)
-		m.Pattern = pattern
-		m.Exclude = exclude
-		m.Recursive = recursive
-		m.AddTOC = !noTOC
-		m.AddDividers = !noDividers
-		m.PreserveStructure = !noStructure
-		m.AddSummary = !noSummary
-		m.OptimizeForClaude = !noClaudeOptimization
+		if watch {
+			w := watcher.New(inputDir)
+			w.Recursive = recursive
+			w.Poll = poll
+			w.Filter = func(path string) bool {
+				return claudedocs.MatchesDir(inputDir, dirOpts, path)
+			}
+			watchAndRun(w, run)
+			return
+		}
 		
-		err := m.Merge()
+		err := run()
 		checkError(err)
 	},
 }
@@ -49,4 +106,10 @@ func init() {
 	mergeCmd.Flags().Bool("no-structure", false, "Skip link processing")
 	mergeCmd.Flags().Bool("no-summary", false, "Skip summary section")
 	mergeCmd.Flags().Bool("no-claude-optimization", false, "Skip Claude optimization")
+	mergeCmd.Flags().String("cache-dir", ".claude/.cache", "Directory for cached processed documents")
+	mergeCmd.Flags().Bool("no-cache", false, "Process every document without using the cache")
+	mergeCmd.Flags().Bool("check", false, "Exit non-zero if the output is stale relative to the inputs")
+	mergeCmd.Flags().Int("jobs", runtime.NumCPU(), "Number of documents to read and process concurrently")
+	addOutputFlags(mergeCmd)
+	addWatchFlags(mergeCmd)
 }
\ No newline at end of file
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/pkg/claudedocs"
+	"github.com/spf13/cobra"
+)
+
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "Print planned file changes without writing them")
+	cmd.Flags().Bool("force", false, "Overwrite existing files")
+}
+
+// newOutputWriter returns the output writer configured by the flags added
+// with addOutputFlags.
+func newOutputWriter(cmd *cobra.Command) *output.Writer {
+	out := output.New()
+	out.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	out.Force, _ = cmd.Flags().GetBool("force")
+	return out
+}
+
+// writeOptions returns the library write options configured by the flags
+// added with addOutputFlags.
+func writeOptions(cmd *cobra.Command) claudedocs.WriteOptions {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	return claudedocs.WriteOptions{DryRun: dryRun, Force: force, Log: os.Stdout}
+}
@@ -25,7 +25,14 @@ func init() {
 	rootCmd.AddCommand(splitCmd)
 	rootCmd.AddCommand(mergeCmd)
 	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(xrefCmd)
 	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(upgradeCmd)
+	rootCmd.AddCommand(sectionCmd)
+	rootCmd.AddCommand(logCmd)
 }
 
 func checkError(err error) {
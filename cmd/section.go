@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/claude-code/claude-doc-structure/internal/section"
+	"github.com/spf13/cobra"
+)
+
+var sectionCmd = &cobra.Command{
+	Use:   "section",
+	Short: "Read and edit document sections by heading path",
+	Long: `Read or change the body under a heading, leaving the rest of the file byte
+for byte as it was.
+
+A heading path lists titles from the outside in, separated by " > ", such as
+"Architecture & Technology Stack > Key Components". Outer headings such as
+the document title can be left out, titles are compared ignoring case, and an
+element written as a heading ("## Setup") only matches that level. A section's
+body runs to the next heading of the same or a higher level, so it includes
+its subsections.`,
+}
+
+var sectionGetCmd = &cobra.Command{
+	Use:   "get <file> <heading path>",
+	Short: "Print the body of a section",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		content := readSectionFile(args[0])
+		body, err := section.Get(content, section.ParsePath(args[1]))
+		checkError(err)
+		fmt.Print(body)
+	},
+}
+
+var sectionSetCmd = &cobra.Command{
+	Use:   "set <file> <heading path>",
+	Short: "Replace the body of a section",
+	Long: `Replace the body of a section, including its subsections, with --text or
+standard input. With --create a missing section is added at the end of its
+parent section, or of the document.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		create, _ := cmd.Flags().GetBool("create")
+		content := readSectionFile(args[0])
+		path := section.ParsePath(args[1])
+		body := sectionInput(cmd)
+
+		updated, err := section.Set(content, path, body)
+		if errors.Is(err, section.ErrNotFound) && create {
+			updated, err = section.Create(content, path, body)
+		}
+		checkError(err)
+		writeSectionFile(cmd, args[0], content, updated)
+	},
+}
+
+var sectionAppendCmd = &cobra.Command{
+	Use:   "append <file> <heading path>",
+	Short: "Add text at the end of a section",
+	Long: `Add --text or standard input at the end of a section, after its
+subsections. List items continue a list the section ends with; other text
+starts a new paragraph.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		content := readSectionFile(args[0])
+		updated, err := section.Append(content, section.ParsePath(args[1]), sectionInput(cmd))
+		checkError(err)
+		writeSectionFile(cmd, args[0], content, updated)
+	},
+}
+
+var sectionDeleteCmd = &cobra.Command{
+	Use:   "delete <file> <heading path>",
+	Short: "Remove a section and its subsections",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		content := readSectionFile(args[0])
+		updated, err := section.Delete(content, section.ParsePath(args[1]))
+		checkError(err)
+		writeSectionFile(cmd, args[0], content, updated)
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{sectionSetCmd, sectionAppendCmd} {
+		cmd.Flags().String("text", "", "Text to write (default: read from standard input)")
+	}
+	sectionSetCmd.Flags().Bool("create", false, "Add the section when it does not exist")
+	for _, cmd := range []*cobra.Command{sectionSetCmd, sectionAppendCmd, sectionDeleteCmd} {
+		cmd.Flags().Bool("dry-run", false, "Print planned file changes without writing them")
+		sectionCmd.AddCommand(cmd)
+	}
+	sectionCmd.AddCommand(sectionGetCmd)
+}
+
+func readSectionFile(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		checkError(fmt.Errorf("failed to read %s: %w", path, err))
+	}
+	return string(content)
+}
+
+// sectionInput returns --text, or standard input when the flag is not set.
+func sectionInput(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("text") {
+		text, _ := cmd.Flags().GetString("text")
+		return text
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		checkError(fmt.Errorf("failed to read standard input: %w", err))
+	}
+	return string(data)
+}
+
+func writeSectionFile(cmd *cobra.Command, path, content, updated string) {
+	if updated == content {
+		return
+	}
+	out := newOutputWriter(cmd)
+	out.Force = true // editing the file is the point of the command
+	_, err := out.WriteFile(path, []byte(updated), 0644)
+	checkError(err)
+}
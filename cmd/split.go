@@ -1,15 +1,25 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/claude-code/claude-doc-structure/internal/splitter"
+	"github.com/claude-code/claude-doc-structure/internal/watcher"
+	"github.com/claude-code/claude-doc-structure/pkg/claudedocs"
 	"github.com/spf13/cobra"
 )
 
 var splitCmd = &cobra.Command{
-	Use:   "split <input-file>",
+	Use:   "split <input>...",
 	Short: "Split large documents",
-	Long:  "Split large documents into smaller, manageable sections.",
-	Args:  cobra.ExactArgs(1),
+	Long: `Split large documents into smaller, manageable sections.
+
+Given a single file, it is always split. Given several files, directories or
+glob patterns, every Markdown file above the threshold is split into a
+directory named after it, and one combined manifest is written.`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		inputFile := args[0]
 		
@@ -20,40 +30,141 @@ var splitCmd = &cobra.Command{
 		linesPerFile, _ := cmd.Flags().GetInt("lines-per-file")
 		maxSizeKB, _ := cmd.Flags().GetInt64("max-size-kb")
 		noNavigation, _ := cmd.Flags().GetBool("no-navigation")
+		manifest, _ := cmd.Flags().GetBool("manifest")
+		index, _ := cmd.Flags().GetBool("index")
+		filenameTemplate, _ := cmd.Flags().GetString("filename-template")
+		numbered, _ := cmd.Flags().GetBool("numbered")
+		update, _ := cmd.Flags().GetBool("update")
 		
 		byLines, _ := cmd.Flags().GetBool("by-lines")
 		bySize, _ := cmd.Flags().GetBool("by-size")
+		bySemantic, _ := cmd.Flags().GetBool("by-semantic")
+		chunkTokens, _ := cmd.Flags().GetInt("chunk-tokens")
+		overlapTokens, _ := cmd.Flags().GetInt("overlap-tokens")
+		watch, _ := cmd.Flags().GetBool("watch")
+		poll, _ := cmd.Flags().GetBool("poll")
+		thresholdTokens, _ := cmd.Flags().GetInt("threshold-tokens")
+		thresholdKB, _ := cmd.Flags().GetInt64("threshold-kb")
 		
-		// Create splitter
-		s := splitter.New(inputFile, outputDir, prefix)
-		s.MaxSections = maxSections
-		s.HeaderLevel = headerLevel
-		s.LinesPerFile = linesPerFile
-		s.MaxSizeKB = maxSizeKB
-		s.AddNavigation = !noNavigation
+		opts := claudedocs.DefaultSplitFileOptions()
+		opts.Prefix = prefix
+		opts.OutputDir = outputDir
+		opts.MaxSections = maxSections
+		opts.HeaderLevel = headerLevel
+		opts.LinesPerFile = linesPerFile
+		opts.MaxSizeKB = maxSizeKB
+		opts.AddNavigation = !noNavigation
+		opts.WriteManifest = manifest
+		opts.WriteIndex = index
+		opts.Update = update
+		opts.FilenameTemplate = filenameTemplate
+		if numbered && !cmd.Flags().Changed("filename-template") {
+			opts.FilenameTemplate = claudedocs.NumberedFilenameTemplate
+		}
+		opts.ChunkTokens = chunkTokens
+		opts.OverlapTokens = overlapTokens
+		opts.ThresholdTokens = thresholdTokens
+		opts.ThresholdKB = thresholdKB
+		write := writeOptions(cmd)
+		conflicts := 0
+		opts.OnEvent = func(e claudedocs.Event) {
+			if e.Kind == claudedocs.FileConflict {
+				conflicts++
+			}
+			printEvent(e)
+		}
 		
 		// Determine split method
-		method := splitter.ByHeaders // default
+		opts.Method = claudedocs.ByHeaders // default
 		if byLines {
-			method = splitter.ByLines
+			opts.Method = claudedocs.ByLines
 		} else if bySize {
-			method = splitter.BySize
+			opts.Method = claudedocs.BySize
+		} else if bySemantic {
+			opts.Method = claudedocs.BySemantic
+		}
+		
+		split := func() error {
+			return claudedocs.SplitFile(cmd.Context(), inputFile, opts, write)
+		}
+		watchPaths := []string{inputFile}
+		if !isSingleFile(args) {
+			split = func() error {
+				return claudedocs.SplitFiles(cmd.Context(), args, opts, write)
+			}
+			watchPaths = splitter.WatchRoots(args)
 		}
 		
-		err := s.Split(method)
+		// Locally edited files that were kept fail the run
+		run := func() error {
+			conflicts = 0
+			if err := split(); err != nil {
+				return err
+			}
+			if conflicts == 1 {
+				return fmt.Errorf("kept 1 locally edited file (use --force to overwrite it)")
+			}
+			if conflicts > 1 {
+				return fmt.Errorf("kept %d locally edited files (use --force to overwrite them)", conflicts)
+			}
+			return nil
+		}
+		
+		if watch {
+			w := watcher.New(watchPaths...)
+			if !isSingleFile(args) {
+				w.Recursive = true
+				w.Filter = isDocumentationPath
+			}
+			w.Poll = poll
+			watchAndRun(w, func() error {
+				err := run()
+				// Later runs replace the sections this run wrote; update
+				// mode tracks them in the manifest instead
+				if !opts.Update {
+					write.Force = true
+				}
+				return err
+			})
+			return
+		}
+		
+		err := run()
 		checkError(err)
 	},
 }
 
+// isSingleFile reports whether args name exactly one existing file, which
+// is split regardless of its size.
+func isSingleFile(args []string) bool {
+	if len(args) != 1 || strings.ContainsAny(args[0], "*?[") {
+		return false
+	}
+	info, err := os.Stat(args[0])
+	return err == nil && !info.IsDir()
+}
+
 func init() {
 	splitCmd.Flags().String("output-dir", "", "Output directory")
 	splitCmd.Flags().String("prefix", "", "Filename prefix")
 	splitCmd.Flags().Bool("by-headers", false, "Split by headers (default)")
 	splitCmd.Flags().Bool("by-lines", false, "Split by line count")
 	splitCmd.Flags().Bool("by-size", false, "Split by file size")
+	splitCmd.Flags().Bool("by-semantic", false, "Split on paragraph and block boundaries for retrieval")
 	splitCmd.Flags().Int("max-sections", 10, "Maximum sections")
 	splitCmd.Flags().Int("header-level", 2, "Header level to split on")
 	splitCmd.Flags().Int("lines-per-file", 200, "Lines per file")
 	splitCmd.Flags().Int64("max-size-kb", 100, "Max file size in KB")
+	splitCmd.Flags().Int("chunk-tokens", 500, "Target tokens per chunk (semantic split)")
+	splitCmd.Flags().Int("overlap-tokens", 50, "Tokens repeated from the previous chunk (semantic split)")
 	splitCmd.Flags().Bool("no-navigation", false, "Skip navigation links")
+	splitCmd.Flags().Bool("manifest", false, "Write index.json and chunks.jsonl describing each section")
+	splitCmd.Flags().Bool("index", false, "Write an index.md entry point linking every section")
+	splitCmd.Flags().String("filename-template", claudedocs.DefaultFilenameTemplate, "Section filename template ({prefix}, {slug}, {n}, {nn})")
+	splitCmd.Flags().Bool("numbered", false, "Prefix section filenames with their position (01-...)")
+	splitCmd.Flags().Int("threshold-tokens", 0, "Only split files above this many tokens (multiple inputs)")
+	splitCmd.Flags().Int64("threshold-kb", 100, "Only split files above this size in KB (multiple inputs)")
+	splitCmd.Flags().Bool("update", false, "Rewrite only changed sections of an earlier split, removing orphaned ones")
+	addOutputFlags(splitCmd)
+	addWatchFlags(splitCmd)
 }
\ No newline at end of file
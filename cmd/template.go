@@ -4,15 +4,31 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/splitter"
 	"github.com/spf13/cobra"
 )
 
 var templateCmd = &cobra.Command{
-	Use:   "template <type> [name]",
+	Use:   "template <type> <name>",
 	Short: "Generate documentation templates",
-	Long:  "Generate documentation templates for API endpoints, screens, features, or language-specific configurations.",
+	Long: `Generate documentation templates for API endpoints, screens, features and other project documents.
+
+Each document is written to the directory of its type and linked from the
+type's index: api to specs/api/<name>.md (indexed in specs/api.md), screen to
+specs/screens/<name>.md (specs/screens.md), feature to specs/features/<name>.md
+(CLAUDE.md), and adr, runbook, data-model, test-plan, incident (or
+debug-entry) and prompt to their own directories with a README.md index. ADRs
+are numbered (specs/adr/0001-<name>.md) and incidents dated
+(.claude/incidents/2006-01-02-<name>.md). Existing documents are only
+replaced with --force.`,
 	Args:  cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		templateType := args[0]
@@ -20,284 +36,161 @@ var templateCmd = &cobra.Command{
 		if len(args) > 1 {
 			name = args[1]
 		}
-		generateTemplate(templateType, name)
+		generateTemplate(templateType, name, newOutputWriter(cmd))
 	},
 }
 
-func generateTemplate(templateType, name string) {
-	templates := map[string]map[string]string{
-		"api": {
-			"filename": "api-endpoint.md",
-			"content": `# {name} API Endpoint
-
-## Overview
-Brief description of what this endpoint does.
-
-## HTTP Method and URL
-` + "```" + `
-GET/POST/PUT/DELETE /api/{name}
-` + "```" + `
-
-## Parameters
-
-### Path Parameters
-- ` + "`id`" + ` (string): Description
-
-### Query Parameters
-- ` + "`param1`" + ` (string, optional): Description
-- ` + "`param2`" + ` (number, required): Description
-
-### Request Body
-` + "```json" + `
-{
-  "field1": "value",
-  "field2": 123
+func init() {
+	addOutputFlags(templateCmd)
 }
-` + "```" + `
 
-## Response
-
-### Success Response (200 OK)
-` + "```json" + `
-{
-  "success": true,
-  "data": {
-    "result": "value"
-  }
+func generateTemplate(templateType, name string, out *output.Writer) {
+	doc, exists := docTypes[templateType]
+	if !exists {
+		checkError(fmt.Errorf("unknown template type: %s (available: %s)", templateType, strings.Join(getKeys(docTypes), ", ")))
+	}
+	generateDocument(templateType, doc, name, out)
 }
-` + "```" + `
-
-### Error Responses
-- ` + "`400 Bad Request`" + `: Invalid parameters
-- ` + "`404 Not Found`" + `: Resource not found
-- ` + "`500 Internal Server Error`" + `: Server error
-
-## Examples
-
-### Request
-` + "```bash" + `
-curl -X GET "http://localhost:3000/api/{name}?param1=value" \
-  -H "Content-Type: application/json"
-` + "```" + `
 
-### Response
-` + "```json" + `
-{
-  "success": true,
-  "data": []
+func getKeys(m map[string]docType) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
-` + "```" + `
-
-## Notes
-Additional implementation notes or considerations.
-`,
-		},
-		"screen": {
-			"filename": "screen-spec.md",
-			"content": `# {name} Screen Specification
-
-## Overview
-Brief description of the screen's purpose and functionality.
-
-## User Stories
-- As a [user type], I want to [goal] so that [benefit]
-- As a [user type], I want to [goal] so that [benefit]
-
-## Layout & Components
-
-### Header Section
-- Component 1: Description and behavior
-- Component 2: Description and behavior
-
-### Main Content
-- Component 1: Description and behavior
-- Component 2: Description and behavior
-
-### Footer/Actions
-- Button 1: What it does
-- Button 2: What it does
-
-## User Interactions
-
-### Primary Actions
-1. Action 1: Step-by-step description
-2. Action 2: Step-by-step description
-
-### Secondary Actions
-- Action A: Description
-- Action B: Description
-
-## Data Requirements
-
-### API Calls
-- ` + "`GET /api/endpoint`" + `: Purpose and when called
-- ` + "`POST /api/endpoint`" + `: Purpose and when called
-
-### State Management
-- State 1: Description and initial value
-- State 2: Description and initial value
-
-## Navigation
-
-### Entry Points
-- From Screen A: Via action/button
-- From Screen B: Via navigation
-
-### Exit Points
-- To Screen C: Via action/button
-- To Screen D: Via navigation
-
-## Validation Rules
-- Field 1: Validation requirements
-- Field 2: Validation requirements
-
-## Error Handling
-- Error Type 1: How it's displayed/handled
-- Error Type 2: How it's displayed/handled
-
-## Responsive Behavior
-Description of how the screen adapts to different screen sizes.
-
-## Accessibility
-- ARIA labels and roles
-- Keyboard navigation
-- Screen reader considerations
-
-## Notes
-Additional implementation notes, design decisions, or technical considerations.
-`,
-		},
-		"feature": {
-			"filename": "feature-spec.md",
-			"content": `# {name} Feature Specification
-
-## Overview
-High-level description of the feature and its business value.
-
-## Requirements
-
-### Functional Requirements
-1. Requirement 1: Detailed description
-2. Requirement 2: Detailed description
-3. Requirement 3: Detailed description
-
-### Non-Functional Requirements
-- Performance: Expected response times, throughput
-- Security: Authentication, authorization, data protection
-- Usability: User experience considerations
-- Compatibility: Browser/platform support
-
-## User Stories
-- As a [user type], I want to [goal] so that [benefit]
-- As a [user type], I want to [goal] so that [benefit]
-- As a [user type], I want to [goal] so that [benefit]
-
-## Technical Design
-
-### Architecture Overview
-Description of how the feature fits into the overall system architecture.
-
-### Components
-- Component 1: Responsibility and interfaces
-- Component 2: Responsibility and interfaces
-- Component 3: Responsibility and interfaces
-
-### Data Model
-` + "```" + `
-Entity 1:
-- field1: type, description
-- field2: type, description
-
-Entity 2:
-- field1: type, description
-- field2: type, description
-` + "```" + `
 
-### API Design
-- ` + "`GET /api/{name}`" + `: List/retrieve resources
-- ` + "`POST /api/{name}`" + `: Create new resource
-- ` + "`PUT /api/{name}/:id`" + `: Update existing resource
-- ` + "`DELETE /api/{name}/:id`" + `: Delete resource
-
-## Implementation Plan
-
-### Phase 1: Core Functionality
-- [ ] Task 1: Description
-- [ ] Task 2: Description
-- [ ] Task 3: Description
-
-### Phase 2: Enhanced Features
-- [ ] Task 1: Description
-- [ ] Task 2: Description
-
-### Phase 3: Polish & Optimization
-- [ ] Task 1: Description
-- [ ] Task 2: Description
-
-## Testing Strategy
-
-### Unit Tests
-- Component 1: Test scenarios
-- Component 2: Test scenarios
-
-### Integration Tests
-- API endpoints: Test scenarios
-- Database operations: Test scenarios
-
-### User Acceptance Tests
-- User Story 1: Test scenarios
-- User Story 2: Test scenarios
-
-## Deployment Considerations
-- Database migrations
-- Configuration changes
-- Feature flags
-- Rollback procedures
-
-## Success Metrics
-- Metric 1: Target value and measurement method
-- Metric 2: Target value and measurement method
-
-## Risks & Mitigation
-- Risk 1: Description and mitigation strategy
-- Risk 2: Description and mitigation strategy
-
-## Future Considerations
-Ideas for future enhancements or related features.
-`,
-		},
+// generateDocument writes a new document of a registered type into its
+// directory and links it from the type's index.
+func generateDocument(typeName string, doc docType, name string, out *output.Writer) {
+	if strings.TrimSpace(name) == "" {
+		checkError(fmt.Errorf("%s templates need a name, e.g. claude-docs template %s <name>", typeName, typeName))
 	}
 	
-	template, exists := templates[templateType]
-	if !exists {
-		fmt.Printf("Unknown template type: %s\n", templateType)
-		fmt.Printf("Available templates: %s\n", strings.Join(getKeys(templates), ", "))
-		return
-	}
-	
-	if name == "" {
-		name = fmt.Sprintf("example-%s", templateType)
+	now := time.Now()
+	slug := splitter.Slugify(name)
+	number := ""
+	filename := slug + ".md"
+	switch doc.Naming {
+	case nameNumbered:
+		next, err := nextNumber(doc.Dir)
+		checkError(err)
+		number = fmt.Sprintf("%04d", next)
+		filename = number + "-" + filename
+	case nameDated:
+		filename = now.Format("2006-01-02") + "-" + filename
 	}
 	
-	filename := strings.Replace(template["filename"], templateType+"-", name+"-", 1)
-	content := strings.ReplaceAll(template["content"], "{name}", name)
+	expand := strings.NewReplacer("{name}", name, "{slug}", slug, "{number}", number, "{date}", now.Format("2006-01-02")).Replace
 	
-	// Create templates directory if it doesn't exist
-	templatesDir := ".claude/templates"
-	err := os.MkdirAll(templatesDir, 0755)
+	err := out.MkdirAll(doc.Dir)
 	checkError(err)
 	
-	filePath := filepath.Join(templatesDir, filename)
-	err = os.WriteFile(filePath, []byte(content), 0644)
+	// Existing documents are only replaced with --force
+	filePath := filepath.Join(doc.Dir, filename)
+	if _, err := os.Stat(filePath); err == nil && !out.Force {
+		checkError(fmt.Errorf("%w: %s (use --force to overwrite)", output.ErrExists, filePath))
+	}
+	_, err = out.WriteFile(filePath, []byte(expand(doc.Content)), 0644)
 	checkError(err)
+	if !out.DryRun {
+		fmt.Printf("Generated template: %s\n", filePath)
+	}
 	
-	fmt.Printf("Generated template: %s\n", filePath)
+	if _, err := os.Stat(doc.Index); os.IsNotExist(err) && doc.IndexTitle == "" {
+		fmt.Printf("Skipped index: %s not found\n", doc.Index)
+		return
+	}
+	target, err := filepath.Rel(filepath.Dir(doc.Index), filePath)
+	checkError(err)
+	updated, err := addIndexEntry(doc, expand(doc.Entry), filepath.ToSlash(target), out)
+	checkError(err)
+	if updated && !out.DryRun {
+		fmt.Printf("Updated index: %s\n", doc.Index)
+	}
 }
 
-func getKeys(m map[string]map[string]string) []string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
+var numberedFile = regexp.MustCompile(`^(\d+)-`)
+
+// nextNumber returns the number after the highest numbered file in dir.
+func nextNumber(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read %s: %w", dir, err)
 	}
-	return keys
-}
\ No newline at end of file
+	highest := 0
+	for _, entry := range entries {
+		if matches := numberedFile.FindStringSubmatch(entry.Name()); matches != nil {
+			if n, err := strconv.Atoi(matches[1]); err == nil && n > highest {
+				highest = n
+			}
+		}
+	}
+	return highest + 1, nil
+}
+
+// addIndexEntry appends a link to target to the list in the index file,
+// under IndexSection when the type has one, creating the index or section
+// when needed. It reports false when the index already links to target.
+func addIndexEntry(doc docType, text, target string, out *output.Writer) (bool, error) {
+	link := fmt.Sprintf("- [%s](%s)", text, target)
+	
+	existing, err := os.ReadFile(doc.Index)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %s: %w", doc.Index, err)
+	}
+	content := string(existing)
+	if strings.Contains(content, "]("+target+")") {
+		return false, nil
+	}
+	if content == "" {
+		content = "# " + doc.IndexTitle + "\n"
+	}
+	
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	end := len(lines)
+	if doc.IndexSection != "" {
+		end = -1
+		for i, line := range lines {
+			if level, title, ok := markdown.ParseHeading(line); ok && level == 2 && strings.EqualFold(title, doc.IndexSection) {
+				// The section runs to the next heading of the same or a higher level
+				end = len(lines)
+				for j := i + 1; j < len(lines); j++ {
+					if level, _, ok := markdown.ParseHeading(lines[j]); ok && level <= 2 {
+						end = j
+						break
+					}
+				}
+				break
+			}
+		}
+		if end < 0 {
+			lines = append(lines, "", "## "+doc.IndexSection)
+			end = len(lines)
+		}
+	}
+	
+	// Insert after the last non-blank line of the section
+	at := end
+	for at > 0 && strings.TrimSpace(lines[at-1]) == "" {
+		at--
+	}
+	insert := []string{link}
+	if at > 0 && !strings.HasPrefix(strings.TrimSpace(lines[at-1]), "- [") {
+		insert = []string{"", link}
+	}
+	if at < len(lines) && strings.TrimSpace(lines[at]) != "" {
+		insert = append(insert, "")
+	}
+	lines = append(lines[:at], append(insert, lines[at:]...)...)
+	content = strings.Join(lines, "\n") + "\n"
+	
+	// The index is edited in place, keeping everything already in it
+	owned := *out
+	owned.Force = true
+	if _, err := owned.WriteFile(doc.Index, []byte(content), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+// chdir changes into dir for the rest of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestNextNumber(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  int
+	}{
+		{"missing directory", nil, 1},
+		{"only other files", []string{"README.md", "template.md"}, 1},
+		{"gaps are not filled", []string{"0001-first.md", "0003-third.md", "README.md"}, 4},
+		{"unpadded numbers", []string{"9-nine.md", "0010-ten.md"}, 11},
+		{"numbers must start the name", []string{"0002-two.md", "draft-0009.md", "v0005.md"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "adr")
+			for _, file := range tt.files {
+				writeTestFile(t, filepath.Join(dir, file), "# "+file+"\n")
+			}
+
+			got, err := nextNumber(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("nextNumber = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateDocument(t *testing.T) {
+	chdir(t, t.TempDir())
+	out := &output.Writer{Log: io.Discard}
+
+	// ADRs continue after the highest number, creating the index
+	writeTestFile(t, "specs/adr/0001-first.md", "# 0001. First\n")
+	writeTestFile(t, "specs/adr/0003-third.md", "# 0003. Third\n")
+	generateDocument("adr", docTypes["adr"], "Use Postgres", out)
+	generateDocument("adr", docTypes["adr"], "Drop MySQL", out)
+
+	if content := readTestFile(t, "specs/adr/0004-use-postgres.md"); !strings.HasPrefix(content, "# 0004. Use Postgres\n") {
+		t.Errorf("ADR starts with %q", strings.SplitN(content, "\n", 2)[0])
+	}
+	want := "# Architecture Decision Records\n\n- [ADR-0004: Use Postgres](0004-use-postgres.md)\n- [ADR-0005: Drop MySQL](0005-drop-mysql.md)\n"
+	if index := readTestFile(t, "specs/adr/README.md"); index != want {
+		t.Errorf("ADR index =\n%s\nwant\n%s", index, want)
+	}
+
+	// Incidents are named after the date
+	date := time.Now().Format("2006-01-02")
+	generateDocument("incident", docTypes["incident"], "Login outage", out)
+	if content := readTestFile(t, ".claude/incidents/"+date+"-login-outage.md"); !strings.HasPrefix(content, "# "+date+": Login outage\n") {
+		t.Errorf("incident starts with %q", strings.SplitN(content, "\n", 2)[0])
+	}
+	if index := readTestFile(t, ".claude/incidents/README.md"); !strings.Contains(index, "- ["+date+": Login outage]("+date+"-login-outage.md)\n") {
+		t.Errorf("incident index is missing the entry:\n%s", index)
+	}
+
+	// Indexes outside the document directory link relative to themselves
+	generateDocument("api", docTypes["api"], "User Management", out)
+	if index := readTestFile(t, "specs/api.md"); !strings.Contains(index, "## Endpoint Index\n\n- [User Management](api/user-management.md)\n") {
+		t.Errorf("API index is missing the entry:\n%s", index)
+	}
+}
+
+func TestAddIndexEntry(t *testing.T) {
+	sectioned := docType{IndexTitle: "API Documentation", IndexSection: "Endpoint Index"}
+	plain := docType{IndexTitle: "Runbooks"}
+
+	tests := []struct {
+		name        string
+		doc         docType
+		existing    string // index content; empty when there is no index
+		want        string
+		wantUpdated bool
+	}{
+		{
+			name:        "creates the index and section",
+			doc:         sectioned,
+			want:        "# API Documentation\n\n## Endpoint Index\n\n- [Users](api/users.md)\n",
+			wantUpdated: true,
+		},
+		{
+			name:        "creates an index without sections",
+			doc:         plain,
+			want:        "# Runbooks\n\n- [Users](api/users.md)\n",
+			wantUpdated: true,
+		},
+		{
+			name:        "adds a missing section",
+			doc:         sectioned,
+			existing:    "# API\n\nIntro.\n",
+			want:        "# API\n\nIntro.\n\n## Endpoint Index\n\n- [Users](api/users.md)\n",
+			wantUpdated: true,
+		},
+		{
+			name:        "appends to the section before the next one",
+			doc:         sectioned,
+			existing:    "# API\n\n## Endpoint Index\n\n- [Orders](api/orders.md)\n\n## Notes\n\nText.\n",
+			want:        "# API\n\n## Endpoint Index\n\n- [Orders](api/orders.md)\n- [Users](api/users.md)\n\n## Notes\n\nText.\n",
+			wantUpdated: true,
+		},
+		{
+			name:        "section heading case is ignored",
+			doc:         sectioned,
+			existing:    "# API\n\n## endpoint index\n- [Orders](api/orders.md)\n",
+			want:        "# API\n\n## endpoint index\n- [Orders](api/orders.md)\n- [Users](api/users.md)\n",
+			wantUpdated: true,
+		},
+		{
+			name:     "existing entry is not repeated",
+			doc:      sectioned,
+			existing: "# API\n\n## Endpoint Index\n\n- [Users](api/users.md)\n\n## Notes\n",
+			want:     "# API\n\n## Endpoint Index\n\n- [Users](api/users.md)\n\n## Notes\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.doc.Index = filepath.Join(t.TempDir(), "index.md")
+			if tt.existing != "" {
+				writeTestFile(t, tt.doc.Index, tt.existing)
+			}
+
+			updated, err := addIndexEntry(tt.doc, "Users", "api/users.md", &output.Writer{Log: io.Discard})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated != tt.wantUpdated {
+				t.Errorf("updated = %t, want %t", updated, tt.wantUpdated)
+			}
+			if got := readTestFile(t, tt.doc.Index); got != tt.want {
+				t.Errorf("index =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPITemplateParses(t *testing.T) {
+	content := strings.NewReplacer("{name}", "Orders", "{slug}", "orders").Replace(docTypes["api"].Content)
+	endpoints, warnings := apispec.Parse("specs/api/orders.md", content)
+	for _, w := range warnings {
+		t.Errorf("warning: %s", w)
+	}
+	if len(endpoints) != 1 {
+		t.Fatalf("got %d endpoints, want 1", len(endpoints))
+	}
+
+	endpoint := endpoints[0]
+	if endpoint.Method != "GET" || endpoint.Path != "/api/orders/{id}" {
+		t.Errorf("endpoint = %s %s, want GET /api/orders/{id}", endpoint.Method, endpoint.Path)
+	}
+	if endpoint.RequestBody != nil {
+		t.Error("GET endpoint has a request body")
+	}
+}
@@ -0,0 +1,528 @@
+package cmd
+
+// naming is how a document type names its files.
+type naming int
+
+const (
+	// namePlain names files after the slug: <slug>.md
+	namePlain naming = iota
+	// nameNumbered numbers files in sequence: 0001-<slug>.md
+	nameNumbered
+	// nameDated prefixes files with the date: 2006-01-02-<slug>.md
+	nameDated
+)
+
+// docType is a template that is generated into its own directory and
+// linked from an index file.
+type docType struct {
+	Dir    string
+	Naming naming
+	// Index is the file linking to the generated documents, relative to
+	// the project root. Links are appended to IndexSection when set, which
+	// is added to the index when missing. Without an IndexTitle, a missing
+	// index is not created.
+	Index        string
+	IndexTitle   string
+	IndexSection string
+	// Entry is the index link text; like Content it may use {name},
+	// {slug} (the name as used in filenames and URLs), {number} and {date}.
+	Entry   string
+	Content string
+}
+
+var docTypes = map[string]docType{
+	"api": {
+		Dir:          "specs/api",
+		Naming:       namePlain,
+		Index:        "specs/api.md",
+		IndexTitle:   "API Documentation",
+		IndexSection: "Endpoint Index",
+		Entry:        "{name}",
+		Content: `# {name} API Endpoint
+
+## Overview
+Brief description of what this endpoint does.
+
+## HTTP Method and URL
+` + "```" + `
+GET /api/{slug}/{id}
+` + "```" + `
+
+## Parameters
+
+### Path Parameters
+- ` + "`id`" + ` (string): Description
+
+### Query Parameters
+- ` + "`param1`" + ` (string, optional): Description
+- ` + "`param2`" + ` (number, required): Description
+
+## Response
+
+### Success Response (200 OK)
+` + "```json" + `
+{
+  "success": true,
+  "data": {
+    "result": "value"
+  }
+}
+` + "```" + `
+
+### Error Responses
+- ` + "`400 Bad Request`" + `: Invalid parameters
+- ` + "`404 Not Found`" + `: Resource not found
+- ` + "`500 Internal Server Error`" + `: Server error
+
+## Examples
+
+### Request
+` + "```bash" + `
+curl -X GET "http://localhost:3000/api/{slug}?param1=value" \
+  -H "Content-Type: application/json"
+` + "```" + `
+
+### Response
+` + "```json" + `
+{
+  "success": true,
+  "data": []
+}
+` + "```" + `
+
+## Notes
+Additional implementation notes or considerations.
+`,
+	},
+	"screen": {
+		Dir:          "specs/screens",
+		Naming:       namePlain,
+		Index:        "specs/screens.md",
+		IndexTitle:   "Screen Specifications",
+		IndexSection: "Screen Index",
+		Entry:        "{name}",
+		Content: `# {name} Screen Specification
+
+## Overview
+Brief description of the screen's purpose and functionality.
+
+## User Stories
+- As a [user type], I want to [goal] so that [benefit]
+- As a [user type], I want to [goal] so that [benefit]
+
+## Layout & Components
+
+### Header Section
+- Component 1: Description and behavior
+- Component 2: Description and behavior
+
+### Main Content
+- Component 1: Description and behavior
+- Component 2: Description and behavior
+
+### Footer/Actions
+- Button 1: What it does
+- Button 2: What it does
+
+## User Interactions
+
+### Primary Actions
+1. Action 1: Step-by-step description
+2. Action 2: Step-by-step description
+
+### Secondary Actions
+- Action A: Description
+- Action B: Description
+
+## Data Requirements
+
+### API Calls
+- ` + "`GET /api/endpoint`" + `: Purpose and when called
+- ` + "`POST /api/endpoint`" + `: Purpose and when called
+
+### State Management
+- State 1: Description and initial value
+- State 2: Description and initial value
+
+## Navigation
+
+### Entry Points
+- From Screen A: Via action/button
+- From Screen B: Via navigation
+
+### Exit Points
+- To Screen C: Via action/button
+- To Screen D: Via navigation
+
+## Validation Rules
+- Field 1: Validation requirements
+- Field 2: Validation requirements
+
+## Error Handling
+- Error Type 1: How it's displayed/handled
+- Error Type 2: How it's displayed/handled
+
+## Responsive Behavior
+Description of how the screen adapts to different screen sizes.
+
+## Accessibility
+- ARIA labels and roles
+- Keyboard navigation
+- Screen reader considerations
+
+## Notes
+Additional implementation notes, design decisions, or technical considerations.
+`,
+	},
+	"feature": {
+		Dir:          "specs/features",
+		Naming:       namePlain,
+		Index:        "CLAUDE.md",
+		IndexSection: "Feature Specifications",
+		Entry:        "{name}",
+		Content: `# {name} Feature Specification
+
+## Overview
+High-level description of the feature and its business value.
+
+## Requirements
+
+### Functional Requirements
+1. Requirement 1: Detailed description
+2. Requirement 2: Detailed description
+3. Requirement 3: Detailed description
+
+### Non-Functional Requirements
+- Performance: Expected response times, throughput
+- Security: Authentication, authorization, data protection
+- Usability: User experience considerations
+- Compatibility: Browser/platform support
+
+## User Stories
+- As a [user type], I want to [goal] so that [benefit]
+- As a [user type], I want to [goal] so that [benefit]
+- As a [user type], I want to [goal] so that [benefit]
+
+## Technical Design
+
+### Architecture Overview
+Description of how the feature fits into the overall system architecture.
+
+### Components
+- Component 1: Responsibility and interfaces
+- Component 2: Responsibility and interfaces
+- Component 3: Responsibility and interfaces
+
+### Data Model
+` + "```" + `
+Entity 1:
+- field1: type, description
+- field2: type, description
+
+Entity 2:
+- field1: type, description
+- field2: type, description
+` + "```" + `
+
+### API Design
+- ` + "`GET /api/{slug}`" + `: List/retrieve resources
+- ` + "`POST /api/{slug}`" + `: Create new resource
+- ` + "`PUT /api/{slug}/:id`" + `: Update existing resource
+- ` + "`DELETE /api/{slug}/:id`" + `: Delete resource
+
+## Implementation Plan
+
+### Phase 1: Core Functionality
+- [ ] Task 1: Description
+- [ ] Task 2: Description
+- [ ] Task 3: Description
+
+### Phase 2: Enhanced Features
+- [ ] Task 1: Description
+- [ ] Task 2: Description
+
+### Phase 3: Polish & Optimization
+- [ ] Task 1: Description
+- [ ] Task 2: Description
+
+## Testing Strategy
+
+### Unit Tests
+- Component 1: Test scenarios
+- Component 2: Test scenarios
+
+### Integration Tests
+- API endpoints: Test scenarios
+- Database operations: Test scenarios
+
+### User Acceptance Tests
+- User Story 1: Test scenarios
+- User Story 2: Test scenarios
+
+## Deployment Considerations
+- Database migrations
+- Configuration changes
+- Feature flags
+- Rollback procedures
+
+## Success Metrics
+- Metric 1: Target value and measurement method
+- Metric 2: Target value and measurement method
+
+## Risks & Mitigation
+- Risk 1: Description and mitigation strategy
+- Risk 2: Description and mitigation strategy
+
+## Future Considerations
+Ideas for future enhancements or related features.
+`,
+	},
+	"adr": {
+		Dir:        "specs/adr",
+		Naming:     nameNumbered,
+		Index:      "specs/adr/README.md",
+		IndexTitle: "Architecture Decision Records",
+		Entry:      "ADR-{number}: {name}",
+		Content: `# {number}. {name}
+
+- **Status**: Proposed
+- **Date**: {date}
+- **Deciders**: Names of the people involved in the decision
+
+## Context
+What is the issue that motivates this decision? Describe the forces at play:
+technical, business, team and schedule constraints.
+
+## Decision
+What is the change that we are proposing or have agreed to?
+
+## Alternatives Considered
+- Option 1: Description, pros and cons
+- Option 2: Description, pros and cons
+
+## Consequences
+
+### Positive
+- What becomes easier
+
+### Negative
+- What becomes harder, and the trade-offs accepted
+
+### Follow-up
+- [ ] Tasks needed to carry out the decision
+
+## References
+- Related ADRs, issues and documentation
+`,
+	},
+	"runbook": {
+		Dir:        "specs/runbooks",
+		Naming:     namePlain,
+		Index:      "specs/runbooks/README.md",
+		IndexTitle: "Runbooks",
+		Entry:      "{name}",
+		Content: `# {name} Runbook
+
+## Overview
+What this runbook is for and the outcome of following it.
+
+## When to Use
+- Alert, symptom or request that triggers this procedure
+
+## Prerequisites
+- Access: Systems and permissions needed
+- Tools: CLI tools and versions
+
+## Procedure
+
+### 1. Assess
+` + "```bash" + `
+# Commands to check the current state
+` + "```" + `
+
+### 2. Act
+` + "```bash" + `
+# Commands that perform the operation
+` + "```" + `
+
+### 3. Verify
+` + "```bash" + `
+# Commands that confirm the operation succeeded
+` + "```" + `
+
+## Rollback
+Steps to undo the procedure if something goes wrong.
+
+## Escalation
+- Owner: Team or person responsible
+- Contact: How to reach them
+
+## Related
+- Dashboards, alerts and documentation
+`,
+	},
+	"data-model": {
+		Dir:        "specs/data-models",
+		Naming:     namePlain,
+		Index:      "specs/data-models/README.md",
+		IndexTitle: "Data Models",
+		Entry:      "{name}",
+		Content: `# {name} Data Model
+
+## Overview
+What the entity represents and which parts of the system own it.
+
+## Fields
+
+| Field | Type | Required | Description |
+|-------|------|----------|-------------|
+| id | string (UUID) | yes | Unique identifier |
+| created_at | timestamp | yes | Creation time |
+| updated_at | timestamp | yes | Last modification time |
+
+## Relationships
+- Belongs to: Parent entity and foreign key
+- Has many: Child entities
+
+## Indexes & Constraints
+- Unique: Fields that must be unique
+- Index: Fields used in frequent queries
+
+## Validation Rules
+- Field 1: Validation requirements
+
+## Lifecycle
+States the entity goes through and what triggers each transition.
+
+## Example
+` + "```json" + `
+{
+  "id": "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+  "created_at": "2024-01-01T00:00:00Z",
+  "updated_at": "2024-01-01T00:00:00Z"
+}
+` + "```" + `
+
+## Migrations
+- Migration history and planned schema changes
+`,
+	},
+	"test-plan": {
+		Dir:        "specs/test-plans",
+		Naming:     namePlain,
+		Index:      "specs/test-plans/README.md",
+		IndexTitle: "Test Plans",
+		Entry:      "{name}",
+		Content: `# {name} Test Plan
+
+## Scope
+
+### In Scope
+- Features and components covered
+
+### Out of Scope
+- What is explicitly not tested, and why
+
+## Test Environment
+- Environment: local/staging/production-like
+- Data: Fixtures and seed data required
+
+## Test Cases
+
+| ID | Scenario | Steps | Expected Result | Priority |
+|----|----------|-------|-----------------|----------|
+| TC-1 | Happy path | 1. Step 2. Step | Outcome | High |
+| TC-2 | Invalid input | 1. Step 2. Step | Error shown | Medium |
+
+## Edge Cases
+- Boundary values, empty states, concurrency
+
+## Automation
+- Unit: What is covered and where the tests live
+- Integration: What is covered and where the tests live
+- End-to-end: What is covered and where the tests live
+
+## Entry & Exit Criteria
+- Entry: Conditions to start testing
+- Exit: Conditions to sign off
+
+## Risks
+- Risk 1: Description and mitigation
+`,
+	},
+	"incident": {
+		Dir:        ".claude/incidents",
+		Naming:     nameDated,
+		Index:      ".claude/incidents/README.md",
+		IndexTitle: "Incidents & Debug Entries",
+		Entry:      "{date}: {name}",
+		Content: `# {date}: {name}
+
+- **Severity**: Low/Medium/High/Critical
+- **Status**: Investigating/Resolved
+
+## Problem
+Detailed description of the issue and its symptoms.
+
+## Impact
+Who and what was affected, and for how long.
+
+## Timeline
+- HH:MM: Event
+
+## Investigation Steps
+` + "```bash" + `
+# Commands used to investigate
+` + "```" + `
+
+## Root Cause
+What was causing the problem.
+
+## Solution
+How the issue was resolved.
+
+## Prevention
+- [ ] Steps taken to prevent recurrence
+`,
+	},
+	"prompt": {
+		Dir:        ".claude/prompts",
+		Naming:     namePlain,
+		Index:      ".claude/prompts/README.md",
+		IndexTitle: "Prompts",
+		Entry:      "{name}",
+		Content: `# {name} Prompt
+
+## Purpose
+What this prompt helps Claude do, and when to reach for it.
+
+## Prompt
+` + "```markdown" + `
+Describe the task here. Reference files with @path/to/file and replace
+<placeholders> with the details of the current task.
+
+Context:
+- <relevant background>
+
+Requirements:
+- <requirement>
+
+Output:
+- <expected format>
+` + "```" + `
+
+## Inputs
+- <placeholder>: What to fill in
+
+## Expected Output
+What a good answer looks like.
+
+## Notes
+- Pitfalls and variations that work well
+`,
+	},
+}
+
+func init() {
+	docTypes["debug-entry"] = docTypes["incident"]
+}
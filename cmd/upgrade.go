@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/upgrade"
+	"github.com/spf13/cobra"
+)
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [directory]",
+	Short: "Merge template improvements into an initialized project",
+	Long: `Bring the files created by init up to date with the current templates.
+
+init records the template version and the generated files in
+.claude-docs.lock. upgrade merges each file section by section against the
+version it was generated from: sections you did not edit follow the new
+template, new template sections are added, and your edits are kept.
+Sections changed both by you and by the template get conflict markers, or
+with --report stay as you wrote them and are listed with the template
+changes in a report. Files with conflicts keep their recorded base, so the
+template changes are offered again by the next upgrade; --force records
+them as upgraded anyway. Files that still hold conflict markers from an
+earlier upgrade are skipped until the markers are resolved. A relative
+--report path is resolved against the directory.
+
+Projects initialized before the lock file existed only get the sections
+that are missing from their files.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		directory := "."
+		if len(args) > 0 {
+			directory = args[0]
+		}
+		report, _ := cmd.Flags().GetString("report")
+		if report != "" && !filepath.IsAbs(report) {
+			report = filepath.Join(directory, report)
+		}
+		out := newOutputWriter(cmd)
+
+		lock, err := upgrade.LoadLock(directory)
+		checkError(err)
+		var files []string
+		if lock == nil {
+			fmt.Printf("No %s found; only sections missing from the files will be added.\n", upgrade.LockFile)
+			lock = &upgrade.Lock{Files: make(map[string]string)}
+			for _, file := range initFiles {
+				if _, err := os.Stat(filepath.Join(directory, file)); err == nil {
+					files = append(files, file)
+				}
+			}
+		} else {
+			if lock.Version > templateVersion {
+				checkError(fmt.Errorf("%s was written by a newer claude-docs (template version %d, this is %d)", upgrade.LockFile, lock.Version, templateVersion))
+			}
+			files = lock.FileNames()
+		}
+
+		name := lock.Answers.Name
+		if name == "" {
+			name = projectNameOf(directory)
+		}
+
+		// Files upgrade merges are ours to overwrite
+		owned := *out
+		owned.Force = true
+
+		var conflicts []string
+		var reportText strings.Builder
+		updated := 0
+		for _, file := range files {
+			path := filepath.Join(directory, file)
+			yours, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				fmt.Printf("Skipped: %s (deleted)\n", path)
+				continue
+			}
+			checkError(err)
+
+			template := renderInitFile(directory, file, name, lock.Answers, files)
+			result := upgrade.Merge(lock.Files[file], string(yours), template, report == "")
+			// The file keeps its old base until the markers are resolved
+			if result.Unresolved {
+				conflicts = append(conflicts, fmt.Sprintf("%s: unresolved conflict markers", path))
+				fmt.Printf("Skipped: %s (resolve its conflict markers first)\n", path)
+				fmt.Fprintf(&reportText, "## %s\n\nThis file still holds conflict markers from an earlier upgrade and was skipped.\n\n", filepath.ToSlash(file))
+				continue
+			}
+			// Keep the old base of conflicting files, so the conflicts are
+			// still detected until they are resolved
+			if len(result.Conflicts) == 0 || out.Force {
+				lock.Files[file] = template
+			}
+
+			if result.Content != string(yours) {
+				_, err := owned.WriteFile(path, []byte(result.Content), 0644)
+				checkError(err)
+				updated++
+				if !out.DryRun {
+					fmt.Printf("Updated: %s\n", path)
+				}
+			}
+			printSections("added", result.Added)
+			printSections("updated", result.Updated)
+			printSections("removed", result.Removed)
+
+			for _, conflict := range result.Conflicts {
+				conflicts = append(conflicts, fmt.Sprintf("%s: %s", path, conflict.Name))
+				fmt.Printf("Conflict: %s: %s\n", path, conflict.Name)
+				fmt.Fprintf(&reportText, "## %s: %s\n\n", filepath.ToSlash(file), conflict.Name)
+				reportText.WriteString("Both your file and the template changed this section. Your version was kept; the template changed it as follows:\n\n")
+				fmt.Fprintf(&reportText, "```diff\n%s```\n\n", output.UnifiedDiff(file, conflict.Base, conflict.Template))
+			}
+		}
+
+		lock.Version = templateVersion
+		data, err := lock.Marshal()
+		checkError(err)
+		_, err = owned.WriteFile(filepath.Join(directory, upgrade.LockFile), data, 0644)
+		checkError(err)
+
+		if report != "" && len(conflicts) > 0 {
+			text := "# Template Upgrade Report\n\n" + reportText.String()
+			_, err := owned.WriteFile(report, []byte(strings.TrimRight(text, "\n")+"\n"), 0644)
+			checkError(err)
+			if !out.DryRun {
+				fmt.Printf("Created: %s\n", report)
+			}
+		}
+
+		if updated == 0 && len(conflicts) == 0 {
+			fmt.Printf("Already up to date (template version %d)\n", templateVersion)
+			return
+		}
+		fmt.Printf("Upgraded to template version %d: %d files updated, %d conflicts\n", templateVersion, updated, len(conflicts))
+		if len(conflicts) > 0 && report == "" {
+			fmt.Println("Resolve the <<<<<<< yours / >>>>>>> template markers in the conflicting sections.")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	upgradeCmd.Flags().String("report", "", "Keep your version of conflicting sections and write the template changes to this file")
+	upgradeCmd.Flags().Bool("dry-run", false, "Print planned file changes without writing them")
+	upgradeCmd.Flags().Bool("force", false, "Record files with conflicts as upgraded")
+}
+
+func printSections(action string, names []string) {
+	for _, name := range names {
+		fmt.Printf("  %s: %s\n", action, name)
+	}
+}
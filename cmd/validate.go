@@ -6,6 +6,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/openapi"
+	"github.com/claude-code/claude-doc-structure/internal/routes"
+	"github.com/claude-code/claude-doc-structure/internal/watcher"
 	"github.com/spf13/cobra"
 )
 
@@ -19,10 +23,78 @@ var validateCmd = &cobra.Command{
 		if len(args) > 0 {
 			directory = args[0]
 		}
-		validateStructure(directory)
+		
+		watch, _ := cmd.Flags().GetBool("watch")
+		poll, _ := cmd.Flags().GetBool("poll")
+		checkAPI, _ := cmd.Flags().GetBool("api")
+		openapiFile, _ := cmd.Flags().GetString("openapi")
+		checkScreens, _ := cmd.Flags().GetBool("screens")
+		
+		// run returns the errors instead of exiting, so that watch mode
+		// keeps watching after a failed check
+		run := func() (int, error) {
+			validateStructure(directory)
+			problems := 0
+			if checkAPI {
+				count, err := validateAPI(directory, openapiFile)
+				if err != nil {
+					return problems, err
+				}
+				problems += count
+			}
+			if checkScreens {
+				count, err := validateScreens(directory)
+				if err != nil {
+					return problems, err
+				}
+				problems += count
+			}
+			return problems, nil
+		}
+		
+		if watch {
+			w := watcher.New(directory)
+			w.Recursive = true
+			w.Poll = poll
+			if !checkAPI {
+				w.Filter = isDocumentationPath
+			}
+			watchAndRun(w, func() error {
+				_, err := run()
+				return err
+			})
+			return
+		}
+		
+		problems, err := run()
+		checkError(err)
+		if problems > 0 {
+			os.Exit(1)
+		}
 	},
 }
 
+func init() {
+	validateCmd.Flags().Bool("api", false, "Check documented API endpoints against routes in the code and the OpenAPI file")
+	validateCmd.Flags().Bool("screens", false, "Check the API calls listed in screen specs against the API docs")
+	validateCmd.Flags().String("openapi", "", "OpenAPI file to compare with (default: openapi.yaml/.yml/.json in the project, specs/, api/ or docs/)")
+	addWatchFlags(validateCmd)
+}
+
+// isDocumentationPath reports whether a change to path can affect the
+// validation result.
+func isDocumentationPath(path string) bool {
+	if strings.HasSuffix(strings.ToLower(path), ".md") {
+		return true
+	}
+	for _, dir := range []string{"specs", ".claude"} {
+		if filepath.Base(path) == dir {
+			return true
+		}
+	}
+	return false
+}
+
 func validateStructure(directory string) {
 	fmt.Printf("Validating documentation structure in: %s\n", directory)
 	
@@ -107,4 +179,111 @@ func validateStructure(directory string) {
 	})
 	
 	fmt.Printf("\nScanned %d markdown files\n", markdownCount)
-}
\ No newline at end of file
+}
+
+// validateAPI compares the endpoints documented under specs/ with the
+// routes registered in the code and with the OpenAPI file, and returns the
+// number of mismatches.
+func validateAPI(directory, openapiFile string) (int, error) {
+	fmt.Printf("\nChecking API documentation against the code in: %s\n", directory)
+	
+	docFiles := apispec.DocFiles(directory)
+	if len(docFiles) == 0 {
+		fmt.Println("\n💡 Recommendations:")
+		fmt.Println("  - No API documentation found - add specs/api.md or run 'claude-docs import openapi'")
+		return 0, nil
+	}
+	
+	documented, err := apispec.ExtractFiles(docFiles)
+	if err != nil {
+		return 0, err
+	}
+	
+	problems := 0
+	report := func(title string, refs []apispec.Reference) {
+		if len(refs) == 0 {
+			return
+		}
+		problems += len(refs)
+		fmt.Printf("\n❌ %s:\n", title)
+		for _, ref := range refs {
+			fmt.Printf("  - %s\n", ref)
+		}
+	}
+	
+	implemented, err := routes.Scan(directory)
+	if err != nil {
+		return 0, err
+	}
+	if len(implemented) == 0 {
+		fmt.Println("\n💡 No routes found in the code - skipping the code comparison")
+	} else {
+		undocumented, stale := apispec.Compare(documented, implemented)
+		report("Undocumented endpoints (in the code, not in the docs)", undocumented)
+		report("Stale endpoints (documented, not in the code)", stale)
+	}
+	
+	if openapiFile == "" {
+		openapiFile = findOpenAPIFile(directory)
+	}
+	if openapiFile != "" {
+		doc, err := openapi.Load(openapiFile)
+		if err != nil {
+			return 0, err
+		}
+		var specified []apispec.Reference
+		for _, endpoint := range doc.API(openapiFile).Endpoints {
+			specified = append(specified, apispec.Reference{Method: endpoint.Method, Path: endpoint.Path, File: openapiFile})
+		}
+		missing, extra := apispec.Compare(documented, specified)
+		report(fmt.Sprintf("Endpoints in %s missing from the docs", filepath.ToSlash(openapiFile)), missing)
+		report(fmt.Sprintf("Documented endpoints missing from %s", filepath.ToSlash(openapiFile)), extra)
+	}
+	
+	if problems == 0 {
+		fmt.Printf("\n✅ All %d documented endpoints are consistent\n", len(documented))
+	}
+	return problems, nil
+}
+
+// validateScreens checks that the API calls listed in the screen specs
+// are documented, and returns the number of calls that are not.
+func validateScreens(directory string) (int, error) {
+	fmt.Printf("\nChecking screen API calls against the API docs in: %s\n", directory)
+	
+	_, x, err := crossReference(directory)
+	if err != nil {
+		return 0, err
+	}
+	
+	undocumented := x.Undocumented()
+	if len(undocumented) > 0 {
+		fmt.Println("\n❌ Screens calling undocumented endpoints:")
+		for _, call := range undocumented {
+			fmt.Printf("  - %s: %s\n", call.Screen, call.Ref)
+		}
+	}
+	if len(x.Unused) > 0 {
+		fmt.Println("\n💡 Documented endpoints no screen uses:")
+		for _, ref := range x.Unused {
+			fmt.Printf("  - %s\n", ref)
+		}
+	}
+	if len(undocumented) == 0 {
+		fmt.Printf("\n✅ All %d screen API calls are documented\n", len(x.Calls))
+	}
+	return len(undocumented), nil
+}
+
+// findOpenAPIFile looks for an OpenAPI document in the usual places.
+func findOpenAPIFile(directory string) string {
+	for _, dir := range []string{"", "specs", "api", "docs"} {
+		for _, name := range []string{"openapi.yaml", "openapi.yml", "openapi.json"} {
+			path := filepath.Join(directory, dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
+		}
+	}
+	return ""
+}
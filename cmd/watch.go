@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/claude-code/claude-doc-structure/internal/watcher"
+	"github.com/spf13/cobra"
+)
+
+// watchAndRun runs fn once and then again after every batch of changes
+// reported by w, until the process is interrupted. Errors from fn are
+// reported but do not stop watching.
+func watchAndRun(w *watcher.Watcher, fn func() error) {
+	if err := fn(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("\nWatching %s for changes (press Ctrl+C to stop)...\n", strings.Join(w.Paths, ", "))
+
+	err := w.Run(ctx, func(changes []watcher.Change) {
+		fmt.Printf("\n[%s] %s\n", time.Now().Format("15:04:05"), summarizeChanges(changes))
+		if err := fn(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	})
+	checkError(err)
+}
+
+func summarizeChanges(changes []watcher.Change) string {
+	const maxListed = 5
+
+	var parts []string
+	for i, change := range changes {
+		if i == maxListed {
+			parts = append(parts, fmt.Sprintf("and %d more", len(changes)-maxListed))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", filepath.ToSlash(filepath.Clean(change.Path)), change.Op))
+	}
+
+	noun := "files"
+	if len(changes) == 1 {
+		noun = "file"
+	}
+	return fmt.Sprintf("%d %s changed: %s", len(changes), noun, strings.Join(parts, ", "))
+}
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("watch", false, "Re-run whenever the inputs change")
+	cmd.Flags().Bool("poll", false, "Poll for changes instead of using filesystem notifications")
+}
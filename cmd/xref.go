@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/screens"
+	"github.com/spf13/cobra"
+)
+
+var xrefCmd = &cobra.Command{
+	Use:   "xref [directory]",
+	Short: "Cross-reference screen API calls with the API docs",
+	Long: `Link the endpoints listed under "API Calls" in the screen specs to the
+endpoints documented in specs/api.md, specs/api-spec.md and specs/api/,
+flag calls to undocumented endpoints and list the endpoints no screen uses.
+
+Use 'claude-docs validate --screens' to fail on undocumented calls.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		directory := "."
+		if len(args) > 0 {
+			directory = args[0]
+		}
+
+		specs, x, err := crossReference(directory)
+		checkError(err)
+
+		fmt.Printf("Screen to API cross-reference in: %s\n", directory)
+		for _, screen := range specs {
+			fmt.Printf("\n%s (%s)\n", screen.Name, filepath.ToSlash(screen.File))
+			calls := 0
+			for _, call := range x.Calls {
+				if call.Screen != screen.Name || call.Ref.File != screen.File {
+					continue
+				}
+				calls++
+				if call.Endpoint != nil {
+					fmt.Printf("  ✅ %s %s → %s:%d\n", call.Ref.Method, call.Ref.Path, filepath.ToSlash(call.Endpoint.File), call.Endpoint.Line)
+				} else {
+					fmt.Printf("  ❌ %s %s (line %d): not documented\n", call.Ref.Method, call.Ref.Path, call.Ref.Line)
+				}
+			}
+			if calls == 0 {
+				fmt.Println("  - No API calls listed")
+			}
+		}
+
+		if len(x.Unused) > 0 {
+			fmt.Println("\n💡 Endpoints no screen uses:")
+			for _, ref := range x.Unused {
+				fmt.Printf("  - %s\n", ref)
+			}
+		}
+
+		fmt.Printf("\n%d API calls, %d undocumented, %d unused endpoints\n", len(x.Calls), len(x.Undocumented()), len(x.Unused))
+	},
+}
+
+// crossReference reads the screen specs and API docs under directory and
+// links the screens' API calls to the documented endpoints.
+func crossReference(directory string) ([]screens.Screen, screens.CrossReference, error) {
+	specs, err := screens.ParseFiles(screens.DocFiles(directory))
+	if err != nil {
+		return nil, screens.CrossReference{}, err
+	}
+	documented, err := apispec.ExtractFiles(apispec.DocFiles(directory))
+	if err != nil {
+		return nil, screens.CrossReference{}, err
+	}
+	return specs, screens.LinkCalls(specs, documented), nil
+}
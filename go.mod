@@ -2,9 +2,14 @@ module github.com/claude-code/claude-doc-structure
 
 go 1.21
 
-require github.com/spf13/cobra v1.8.0
+require (
+	github.com/fsnotify/fsnotify v1.8.0
+	github.com/spf13/cobra v1.8.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/sys v0.13.0 // indirect
 )
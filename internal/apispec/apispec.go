@@ -0,0 +1,123 @@
+// Package apispec models the HTTP endpoints documented under specs/ and
+// renders them in the layout of the api template.
+package apispec
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// API is a documented HTTP API.
+type API struct {
+	Title       string
+	Version     string
+	Description string
+	BaseURL     string
+	// Source names the document the API was generated from.
+	Source string
+	// Tags describes endpoint groups by name.
+	Tags      map[string]string
+	Endpoints []Endpoint
+}
+
+type Endpoint struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	// Tag groups the endpoint; endpoints without one are "General".
+	Tag         string
+	Deprecated  bool
+	Parameters  []Parameter
+	RequestBody *Body
+	Responses   []Response
+}
+
+type Parameter struct {
+	Name string
+	// In is path, query, header or cookie.
+	In          string
+	Type        string
+	Required    bool
+	Description string
+	Example     string
+}
+
+type Body struct {
+	ContentType string
+	Description string
+	Required    bool
+	// Example is the rendered example, JSON for JSON bodies.
+	Example string
+}
+
+type Response struct {
+	// Status is an HTTP status code, a range such as 4XX, or "default".
+	Status      string
+	Description string
+	ContentType string
+	Example     string
+}
+
+// DefaultTag groups endpoints without a tag.
+const DefaultTag = "General"
+
+// Key identifies an endpoint as "METHOD /path".
+func (e Endpoint) Key() string {
+	return e.Method + " " + e.Path
+}
+
+// Success reports whether the response is a 2xx response.
+func (r Response) Success() bool {
+	return strings.HasPrefix(r.Status, "2")
+}
+
+// StatusLine returns the status with its reason phrase, e.g. "404 Not Found".
+func (r Response) StatusLine() string {
+	var code int
+	if _, err := fmt.Sscanf(r.Status, "%d", &code); err == nil {
+		if text := http.StatusText(code); text != "" {
+			return fmt.Sprintf("%d %s", code, text)
+		}
+	}
+	return r.Status
+}
+
+// GroupByTag returns the API's tags in order with their endpoints.
+func (api API) GroupByTag() ([]string, map[string][]Endpoint) {
+	groups := make(map[string][]Endpoint)
+	for _, endpoint := range api.Endpoints {
+		tag := endpoint.Tag
+		if tag == "" {
+			tag = DefaultTag
+		}
+		groups[tag] = append(groups[tag], endpoint)
+	}
+
+	tags := make([]string, 0, len(groups))
+	for tag := range groups {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags, groups
+}
+
+// Anchor returns the GitHub-style fragment for a heading.
+func Anchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || isAlphanumeric(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isAlphanumeric(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127
+}
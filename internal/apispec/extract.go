@@ -0,0 +1,180 @@
+package apispec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AnyMethod is the method of routes that accept every method.
+const AnyMethod = "*"
+
+// Reference is an endpoint mentioned in documentation or registered in code.
+type Reference struct {
+	Method string
+	Path   string
+	File   string
+	Line   int
+}
+
+// Key identifies the reference by method and normalized path.
+func (r Reference) Key() string {
+	return r.Method + " " + NormalizePath(r.Path)
+}
+
+func (r Reference) String() string {
+	if r.Line == 0 {
+		return fmt.Sprintf("%s %s (%s)", r.Method, r.Path, filepath.ToSlash(r.File))
+	}
+	return fmt.Sprintf("%s %s (%s:%d)", r.Method, r.Path, filepath.ToSlash(r.File), r.Line)
+}
+
+var (
+	endpointPattern = regexp.MustCompile("\\b(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)\\s+(/[^\\s`\"'()<>\\]|]*)")
+
+	pathParamPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\{[^}]*\}`), // {id}, {id...}
+		regexp.MustCompile(`:[A-Za-z_][A-Za-z0-9_]*`),
+		regexp.MustCompile(`<[^>]*>`),    // <id>, <int:id>
+		regexp.MustCompile(`\[[^\]]*\]`), // [id]
+	}
+)
+
+// Extract returns the METHOD /path entries mentioned in API documentation,
+// such as endpoint headings and method-and-URL blocks, once per endpoint.
+// Method lists such as GET/POST /path are placeholders, not endpoints.
+func Extract(file, content string) []Reference {
+	var refs []Reference
+	seen := make(map[string]bool)
+
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "curl ") {
+			continue
+		}
+		for _, match := range endpointPattern.FindAllStringSubmatchIndex(line, -1) {
+			if match[0] > 0 && line[match[0]-1] == '/' {
+				continue
+			}
+			method, path := line[match[2]:match[3]], line[match[4]:match[5]]
+			ref := Reference{Method: method, Path: strings.TrimRight(path, ".,;:"), File: file, Line: i + 1}
+			if !seen[ref.Key()] {
+				seen[ref.Key()] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+// NormalizePath reduces a route path to a form shared by documentation
+// and the common routers: parameters in any notation ({id}, :id, <id>,
+// [id]) and wildcards become {}, and query strings and trailing slashes
+// are dropped.
+func NormalizePath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.ReplaceAll(path, "{$}", "")
+
+	segments := strings.Split(path, "/")
+	var kept []string
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if segment == "*" {
+			segment = "{}"
+		}
+		for _, pattern := range pathParamPatterns {
+			segment = pattern.ReplaceAllString(segment, "{}")
+		}
+		kept = append(kept, segment)
+	}
+	return "/" + strings.Join(kept, "/")
+}
+
+// DocFiles returns the API documentation files under dir: specs/api.md,
+// specs/api-spec.md and the Markdown files under specs/api/.
+func DocFiles(dir string) []string {
+	var files []string
+	for _, name := range []string{"api.md", "api-spec.md"} {
+		path := filepath.Join(dir, "specs", name)
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+
+	apiDir := filepath.Join(dir, "specs", "api")
+	var nested []string
+	filepath.Walk(apiDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".md") {
+			nested = append(nested, path)
+		}
+		return nil
+	})
+	sort.Strings(nested)
+
+	return append(files, nested...)
+}
+
+// ExtractFiles reads files and returns the endpoints they mention, once
+// per endpoint.
+func ExtractFiles(files []string) ([]Reference, error) {
+	var refs []Reference
+	seen := make(map[string]bool)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		for _, ref := range Extract(file, string(content)) {
+			if !seen[ref.Key()] {
+				seen[ref.Key()] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs, nil
+}
+
+// Compare matches documented endpoints against implemented ones. It
+// returns the implemented endpoints that are not documented and the
+// documented endpoints that are not implemented. Implemented routes that
+// accept any method match documentation for every method.
+func Compare(documented, implemented []Reference) (undocumented, stale []Reference) {
+	byPath := func(refs []Reference) map[string]map[string]bool {
+		index := make(map[string]map[string]bool)
+		for _, ref := range refs {
+			path := NormalizePath(ref.Path)
+			if index[path] == nil {
+				index[path] = make(map[string]bool)
+			}
+			index[path][ref.Method] = true
+		}
+		return index
+	}
+	docs := byPath(documented)
+	code := byPath(implemented)
+
+	for _, ref := range implemented {
+		methods := docs[NormalizePath(ref.Path)]
+		if methods[ref.Method] || (ref.Method == AnyMethod && len(methods) > 0) {
+			continue
+		}
+		undocumented = append(undocumented, ref)
+	}
+
+	for _, ref := range documented {
+		methods := code[NormalizePath(ref.Path)]
+		if methods[ref.Method] || methods[AnyMethod] {
+			continue
+		}
+		stale = append(stale, ref)
+	}
+
+	return undocumented, stale
+}
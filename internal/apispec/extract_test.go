@@ -0,0 +1,54 @@
+package apispec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "heading and block",
+			content: "### GET /api/users\n\n```\nPOST /api/users/{id}\n```\n",
+			want:    []string{"GET /api/users", "POST /api/users/{}"},
+		},
+		{
+			name:    "method list is a placeholder",
+			content: "```\nGET/POST/PUT/DELETE /api/My Feature\n```\n",
+		},
+		{
+			name:    "method list next to a real endpoint",
+			content: "GET/POST /api/things, or DELETE /api/things/:id.\n",
+			want:    []string{"DELETE /api/things/{}"},
+		},
+		{
+			name:    "curl examples are skipped",
+			content: "curl -X GET \"http://localhost:3000/api/users\"\n",
+		},
+		{
+			name:    "repeated endpoints are listed once",
+			content: "## GET /api/users\n\nSee `GET /api/users`.\n",
+			want:    []string{"GET /api/users"},
+		},
+		{
+			name:    "trailing punctuation is dropped",
+			content: "Call PATCH /api/users/:id.\n",
+			want:    []string{"PATCH /api/users/{}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, ref := range Extract("api.md", tt.content) {
+				got = append(got, ref.Key())
+			}
+			if strings.Join(got, "; ") != strings.Join(tt.want, "; ") {
+				t.Errorf("Extract = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package apispec
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/splitter"
+)
+
+// generatedMarker starts every file written by a Generator, so that later
+// runs may replace or remove them without --force.
+const generatedMarker = "<!-- Generated by claude-docs import openapi"
+
+// Generator writes an API as Markdown: one file per tag under Dir, split
+// into numbered parts above MaxSizeKB, and an index at IndexFile.
+type Generator struct {
+	IndexFile string
+	Dir       string
+	MaxSizeKB int64
+	Output    *output.Writer
+	OnEvent   events.Handler
+}
+
+func New(indexFile, dir string) *Generator {
+	return &Generator{
+		IndexFile: indexFile,
+		Dir:       dir,
+		MaxSizeKB: 100,
+		Output:    output.New(),
+	}
+}
+
+type generatedFile struct {
+	path    string
+	content string
+	// endpoints lists the endpoints documented in the file, in order.
+	endpoints []Endpoint
+}
+
+// Generate writes the API documentation. Files written by an earlier run
+// are replaced, and those no longer generated are removed; other existing
+// files are only replaced with Force.
+func (g *Generator) Generate(api API) error {
+	marker := fmt.Sprintf("%s from %s. Edit the source spec and re-import instead. -->\n\n", generatedMarker, api.Source)
+	files := g.tagFiles(api, marker)
+
+	// The index goes first, so that a hand-written index is refused
+	// before anything else is written
+	if err := g.write(g.IndexFile, g.renderIndex(api, files, marker)); err != nil {
+		return err
+	}
+
+	if err := g.Output.MkdirAll(g.Dir); err != nil {
+		return err
+	}
+
+	written := make(map[string]bool, len(files))
+	for _, file := range files {
+		if err := g.write(file.path, file.content); err != nil {
+			return err
+		}
+		written[filepath.Clean(file.path)] = true
+	}
+
+	return g.removeStale(written)
+}
+
+// tagFiles renders the endpoints of each tag, starting a new part whenever
+// a file would exceed MaxSizeKB.
+func (g *Generator) tagFiles(api API, marker string) []generatedFile {
+	tags, groups := api.GroupByTag()
+	maxBytes := int(g.MaxSizeKB * 1024)
+
+	var files []generatedFile
+	for _, tag := range tags {
+		slug := splitter.Slugify(tag)
+		part := 0
+		var current *generatedFile
+
+		start := func() {
+			part++
+			name := slug + ".md"
+			title := fmt.Sprintf("%s API", tag)
+			if part > 1 {
+				name = fmt.Sprintf("%s-%d.md", slug, part)
+				title = fmt.Sprintf("%s API (Part %d)", tag, part)
+			}
+			header := fmt.Sprintf("%s# %s\n\n", marker, title)
+			if description := strings.TrimSpace(api.Tags[tag]); description != "" {
+				header += description + "\n\n"
+			}
+			files = append(files, generatedFile{
+				path:    filepath.Join(g.Dir, name),
+				content: header,
+			})
+			current = &files[len(files)-1]
+		}
+
+		for _, endpoint := range groups[tag] {
+			section := RenderEndpoint(endpoint, api.BaseURL)
+			if current == nil || (maxBytes > 0 && len(current.endpoints) > 0 && len(current.content)+len(section) > maxBytes) {
+				start()
+			}
+			if len(current.endpoints) > 0 {
+				current.content += "---\n\n"
+			}
+			current.content += section + "\n"
+			current.endpoints = append(current.endpoints, endpoint)
+		}
+	}
+
+	for i := range files {
+		files[i].content = strings.TrimRight(files[i].content, "\n") + "\n"
+	}
+	return files
+}
+
+func (g *Generator) renderIndex(api API, files []generatedFile, marker string) string {
+	var b strings.Builder
+
+	title := api.Title
+	if title == "" {
+		title = "API"
+	}
+	b.WriteString(marker)
+	b.WriteString(fmt.Sprintf("# %s Documentation\n\n", title))
+	if description := strings.TrimSpace(api.Description); description != "" {
+		b.WriteString(description + "\n\n")
+	}
+	if api.Version != "" {
+		b.WriteString(fmt.Sprintf("- **Version:** %s\n", api.Version))
+	}
+	if api.BaseURL != "" {
+		b.WriteString(fmt.Sprintf("- **Base URL:** `%s`\n", api.BaseURL))
+	}
+	b.WriteString(fmt.Sprintf("- **Source:** `%s`\n\n", api.Source))
+
+	b.WriteString("## Endpoints\n")
+
+	indexDir := filepath.Dir(g.IndexFile)
+	lastTag := ""
+	for _, file := range files {
+		link, err := filepath.Rel(indexDir, file.path)
+		if err != nil {
+			link = file.path
+		}
+		link = filepath.ToSlash(link)
+
+		tag := file.endpoints[0].Tag
+		if tag == "" {
+			tag = DefaultTag
+		}
+		if tag != lastTag {
+			b.WriteString(fmt.Sprintf("\n### %s\n", tag))
+			lastTag = tag
+		}
+		for _, endpoint := range file.endpoints {
+			summary := oneLine(endpoint.Summary)
+			if summary != "" {
+				summary = ": " + summary
+			}
+			b.WriteString(fmt.Sprintf("- [`%s`](%s#%s)%s\n", endpoint.Key(), link, Anchor(endpoint.Key()), summary))
+		}
+	}
+
+	return b.String()
+}
+
+// write writes a generated file, replacing it without Force only when it
+// was generated before.
+func (g *Generator) write(path, content string) error {
+	out := g.Output
+	if isGenerated(path) {
+		owned := *g.Output
+		owned.Force = true
+		out = &owned
+	}
+
+	action, err := out.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		return err
+	}
+	if !out.DryRun {
+		if action == output.Unchanged {
+			g.OnEvent.Emit(events.Event{Kind: events.FileUnchanged, Path: path})
+		} else {
+			g.OnEvent.Emit(events.Event{Kind: events.FileWritten, Path: path, Replaced: action == output.Overwrite})
+		}
+	}
+	return nil
+}
+
+// removeStale removes generated files in Dir that this run did not write,
+// such as the files of tags no longer in the spec.
+func (g *Generator) removeStale(written map[string]bool) error {
+	entries, err := os.ReadDir(g.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", g.Dir, err)
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(g.Dir, entry.Name())
+		if entry.IsDir() || written[filepath.Clean(path)] || !isGenerated(path) {
+			continue
+		}
+		if err := g.Output.Remove(path); err != nil {
+			return err
+		}
+		if !g.Output.DryRun {
+			g.OnEvent.Emit(events.Event{Kind: events.FileRemoved, Path: path})
+		}
+	}
+	return nil
+}
+
+func isGenerated(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && bytes.HasPrefix(data, []byte(generatedMarker))
+}
@@ -0,0 +1,110 @@
+package apispec
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+func TestGenerate(t *testing.T) {
+	api := API{
+		Title:   "Shop",
+		Version: "2",
+		Source:  "shop.yaml",
+		Tags:    map[string]string{"Orders": "Order handling"},
+		Endpoints: []Endpoint{
+			{Method: "GET", Path: "/orders", Tag: "Orders", Summary: "List orders"},
+			{Method: "POST", Path: "/orders", Tag: "Orders", Description: strings.Repeat("long ", 300)},
+			{Method: "GET", Path: "/health"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		maxSizeKB int64
+		wantFiles []string
+	}{
+		{"one file per tag", 100, []string{"general.md", "orders.md"}},
+		{"split by size", 1, []string{"general.md", "orders-2.md", "orders.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			g := New(filepath.Join(dir, "api.md"), filepath.Join(dir, "api"))
+			g.MaxSizeKB = tt.maxSizeKB
+			g.Output = &output.Writer{Log: io.Discard}
+			if err := g.Generate(api); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := listDir(t, g.Dir); strings.Join(got, ",") != strings.Join(tt.wantFiles, ",") {
+				t.Errorf("files = %v, want %v", got, tt.wantFiles)
+			}
+			index, err := os.ReadFile(g.IndexFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range []string{"# Shop Documentation", "- **Version:** 2", "### Orders", "](api/orders.md#get-orders): List orders", "### General"} {
+				if !strings.Contains(string(index), want) {
+					t.Errorf("index is missing %q:\n%s", want, index)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateReplacesOnlyGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	g := New(filepath.Join(dir, "api.md"), filepath.Join(dir, "api"))
+	g.Output = &output.Writer{Log: io.Discard}
+
+	api := API{Source: "shop.yaml", Endpoints: []Endpoint{
+		{Method: "GET", Path: "/orders", Tag: "Orders"},
+		{Method: "GET", Path: "/users", Tag: "Users"},
+	}}
+	if err := g.Generate(api); err != nil {
+		t.Fatal(err)
+	}
+	notes := filepath.Join(g.Dir, "notes.md")
+	if err := os.WriteFile(notes, []byte("# Notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A re-import replaces generated files and removes those of dropped tags
+	api.Endpoints = api.Endpoints[:1]
+	if err := g.Generate(api); err != nil {
+		t.Fatal(err)
+	}
+	if got := listDir(t, g.Dir); strings.Join(got, ",") != "notes.md,orders.md" {
+		t.Errorf("files after re-import = %v, want notes.md and orders.md", got)
+	}
+
+	// A hand-written index is refused without Force
+	if err := os.WriteFile(g.IndexFile, []byte("# API\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Generate(api); !errors.Is(err, output.ErrExists) {
+		t.Errorf("error = %v, want %v", err, output.ErrExists)
+	}
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,585 @@
+package apispec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+// Warning is a part of the documentation that could not be parsed.
+type Warning struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Line == 0 {
+		return fmt.Sprintf("%s: %s", filepath.ToSlash(w.File), w.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", filepath.ToSlash(w.File), w.Line, w.Message)
+}
+
+type sectionKind int
+
+const (
+	sectionUnknown sectionKind = iota
+	sectionOverview
+	sectionMethodURL
+	sectionParameters
+	sectionPathParameters
+	sectionQueryParameters
+	sectionHeaderParameters
+	sectionCookieParameters
+	sectionRequestBody
+	sectionResponse
+	sectionSuccess
+	sectionStatusList
+	sectionIgnored
+)
+
+var (
+	endpointHeading = regexp.MustCompile(`^(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)\s+(/\S*)$`)
+	boldLabel       = regexp.MustCompile(`^\*\*([^*]+?):?\*\*:?[ \t]*(.*)$`)
+	statusPattern   = regexp.MustCompile(`\b([1-5]\d{2}|[1-5]XX)\b`)
+	tagSuffix       = regexp.MustCompile(`\s+API(\s+\(Part \d+\))?$`)
+	contentTypeLine = regexp.MustCompile("^Content type: `([^`]+)`(.*)$")
+	pathParam       = regexp.MustCompile(`\{([^}]+)\}`)
+
+	parameterItem = regexp.MustCompile("^\\s*[-*+]\\s+`?([A-Za-z_][\\w.\\-\\[\\]]*)`?\\s*(?:\\(([^)]*)\\))?\\s*:?\\s*(.*)$")
+	statusItem    = regexp.MustCompile("^\\s*[-*+]\\s+`?([1-5]\\d{2}|[1-5]XX|default)\\b[^`:]*`?\\s*:?\\s*(.*)$")
+	listItemStart = regexp.MustCompile(`^\s*[-*+]\s+`)
+)
+
+// classifySection maps a section heading or bold label to its role.
+func classifySection(title string) sectionKind {
+	title = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(title), ":")))
+	switch {
+	case title == "overview" || title == "description" || title == "summary":
+		return sectionOverview
+	case title == "http method and url" || title == "endpoint" || title == "url":
+		return sectionMethodURL
+	case title == "parameters":
+		return sectionParameters
+	case title == "path parameters":
+		return sectionPathParameters
+	case title == "query parameters":
+		return sectionQueryParameters
+	case title == "header parameters" || title == "headers":
+		return sectionHeaderParameters
+	case title == "cookie parameters":
+		return sectionCookieParameters
+	case title == "request body" || title == "request":
+		return sectionRequestBody
+	case title == "response" || title == "responses":
+		return sectionResponse
+	case strings.HasPrefix(title, "success response"):
+		return sectionSuccess
+	case title == "error responses" || title == "status codes":
+		return sectionStatusList
+	case title == "examples" || title == "example" || title == "notes" || title == "error response":
+		return sectionIgnored
+	default:
+		return sectionUnknown
+	}
+}
+
+// unit is the span of blocks documenting one endpoint.
+type unit struct {
+	start, end int
+	level      int
+	method     string
+	path       string
+	tag        string
+}
+
+// Parse reads the endpoints documented in content, which may use the
+// layout of the api template (a document whose "HTTP Method and URL"
+// section names the endpoint), of imported specs ("## GET /path" sections)
+// or of the api-spec template ("#### GET /path" with bold labels).
+// Sections that cannot be understood are reported as warnings.
+func Parse(file, content string) ([]Endpoint, []Warning) {
+	blocks := markdown.Blocks(content)
+	var warnings []Warning
+	warn := func(line int, format string, args ...any) {
+		warnings = append(warnings, Warning{File: file, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	units := findUnits(blocks)
+	endpoints := make([]Endpoint, 0, len(units))
+	for _, u := range units {
+		endpoint, ok := parseUnit(blocks, u, warn)
+		if ok {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, warnings
+}
+
+// ParseInfo reads the API version and base URL listed before the first
+// section of an index written by import openapi, such as
+// "- **Version:** 1.2.0" and "- **Base URL:** `https://api.example.com`".
+func ParseInfo(content string) API {
+	var api API
+	for _, block := range markdown.Blocks(content) {
+		if block.Kind == markdown.Heading && block.Level > 1 {
+			break
+		}
+		if block.Kind != markdown.List {
+			continue
+		}
+		for _, item := range listItems(block.Text) {
+			matches := boldLabel.FindStringSubmatch(listItemStart.ReplaceAllString(item, ""))
+			if matches == nil {
+				continue
+			}
+			value := strings.Trim(strings.TrimSpace(matches[2]), "`")
+			switch strings.ToLower(strings.TrimSpace(matches[1])) {
+			case "version":
+				api.Version = value
+			case "base url", "server":
+				api.BaseURL = value
+			}
+		}
+	}
+	return api
+}
+
+func findUnits(blocks []markdown.Block) []unit {
+	var units []unit
+	inUnit := func(i int) bool {
+		for _, u := range units {
+			if i == u.start {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, block := range blocks {
+		if block.Kind != markdown.Heading {
+			continue
+		}
+
+		start := -1
+		if endpointHeading.MatchString(block.Title) {
+			start = i
+		} else if classifySection(block.Title) == sectionMethodURL {
+			// The endpoint is the section enclosing "HTTP Method and URL"
+			for j := i - 1; j >= 0; j-- {
+				if blocks[j].Kind == markdown.Heading && blocks[j].Level < block.Level {
+					start = j
+					break
+				}
+			}
+			if start >= 0 && (endpointHeading.MatchString(blocks[start].Title) || inUnit(start)) {
+				continue
+			}
+		}
+		if start < 0 {
+			continue
+		}
+
+		u := unit{start: start, end: len(blocks), level: blocks[start].Level}
+		for j := start + 1; j < len(blocks); j++ {
+			if blocks[j].Kind == markdown.Heading && blocks[j].Level <= u.level {
+				u.end = j
+				break
+			}
+		}
+		if matches := endpointHeading.FindStringSubmatch(blocks[start].Title); matches != nil {
+			u.method, u.path = matches[1], matches[2]
+		}
+		// Endpoints are grouped by the heading enclosing them
+		for j := start - 1; j >= 0; j-- {
+			if blocks[j].Kind == markdown.Heading && blocks[j].Level < u.level {
+				if tag := tagSuffix.ReplaceAllString(blocks[j].Title, ""); !strings.EqualFold(tag, "Endpoints") {
+					u.tag = tag
+				}
+				break
+			}
+		}
+		units = append(units, u)
+	}
+	return units
+}
+
+func parseUnit(blocks []markdown.Block, u unit, warn func(int, string, ...any)) (Endpoint, bool) {
+	endpoint := Endpoint{Method: u.method, Path: u.path, Tag: u.tag}
+	heading := blocks[u.start]
+
+	top := sectionOverview
+	current := sectionOverview
+	enter := func(kind sectionKind, relative int, title string, line int) {
+		if kind == sectionUnknown {
+			if top != sectionIgnored || relative == 1 {
+				warn(line, "%s: unrecognized section %q ignored", heading.Title, title)
+			}
+			kind = sectionIgnored
+		}
+		if relative <= 1 {
+			top = kind
+			current = kind
+		} else if top == sectionIgnored {
+			current = sectionIgnored
+		} else {
+			current = kind
+		}
+	}
+
+	var success *Response
+	for _, block := range splitLabels(blocks[u.start+1 : u.end]) {
+		if block.Kind == markdown.Heading {
+			enter(classifySection(block.Title), block.Level-u.level, block.Title, block.StartLine)
+			if current == sectionSuccess {
+				endpoint.Responses = append(endpoint.Responses, Response{Status: statusOf(block.Title, "200")})
+				success = &endpoint.Responses[len(endpoint.Responses)-1]
+			}
+			continue
+		}
+
+		text := strings.TrimSpace(block.Text)
+		if text == noDescription {
+			continue
+		}
+		if block.Kind == markdown.Paragraph {
+			if matches := boldLabel.FindStringSubmatch(firstLine(text)); matches != nil {
+				enter(classifySection(matches[1]), 1, matches[1], block.StartLine)
+				// Text after the label belongs to its section
+				text = strings.TrimSpace(matches[2] + "\n" + restLines(text))
+				if text == "" || current == sectionIgnored {
+					continue
+				}
+			}
+		}
+
+		switch current {
+		case sectionOverview:
+			switch block.Kind {
+			case markdown.Paragraph:
+				if endpoint.Summary == "" && endpoint.Description == "" {
+					endpoint.Summary = text
+				} else {
+					endpoint.Description = joinText(endpoint.Description, text)
+				}
+			case markdown.Quote:
+				if strings.Contains(strings.ToLower(text), "deprecated") {
+					endpoint.Deprecated = true
+				}
+			}
+
+		case sectionMethodURL:
+			if block.Kind != markdown.Fence {
+				continue
+			}
+			line := strings.TrimSpace(fenceBody(block.Text))
+			if i := strings.IndexByte(line, '\n'); i >= 0 {
+				line = line[:i]
+			}
+			fields := strings.Fields(line)
+			if len(fields) < 2 || !httpMethod(fields[0]) {
+				warn(block.StartLine, "%s: cannot read a single method and path from %q", heading.Title, line)
+				continue
+			}
+			endpoint.Method, endpoint.Path = fields[0], fields[1]
+
+		case sectionParameters, sectionPathParameters, sectionQueryParameters, sectionHeaderParameters, sectionCookieParameters:
+			if block.Kind != markdown.List {
+				continue
+			}
+			for i, item := range listItems(block.Text) {
+				param, ok := parseParameter(item, current)
+				if !ok {
+					warn(block.StartLine+i, "%s: cannot parse parameter %q", heading.Title, strings.TrimSpace(item))
+					continue
+				}
+				endpoint.Parameters = append(endpoint.Parameters, param)
+			}
+
+		case sectionRequestBody:
+			if endpoint.RequestBody == nil {
+				endpoint.RequestBody = &Body{ContentType: "application/json"}
+			}
+			body := endpoint.RequestBody
+			switch block.Kind {
+			case markdown.Fence:
+				body.Example = fenceBody(block.Text)
+				checkJSON(block, body.ContentType, warn, heading.Title, "request body")
+			case markdown.Paragraph:
+				if matches := contentTypeLine.FindStringSubmatch(text); matches != nil {
+					body.ContentType = matches[1]
+					body.Required = strings.Contains(matches[2], "required")
+				} else {
+					body.Description = joinText(body.Description, text)
+				}
+			}
+
+		case sectionResponse, sectionSuccess:
+			if success == nil {
+				endpoint.Responses = append(endpoint.Responses, Response{Status: "200"})
+				success = &endpoint.Responses[len(endpoint.Responses)-1]
+			}
+			switch block.Kind {
+			case markdown.Fence:
+				if success.Example == "" {
+					success.Example = fenceBody(block.Text)
+					success.ContentType = "application/json"
+					checkJSON(block, success.ContentType, warn, heading.Title, "response")
+				}
+			case markdown.Paragraph:
+				success.Description = joinText(success.Description, text)
+			}
+
+		case sectionStatusList:
+			if block.Kind != markdown.List {
+				continue
+			}
+			for i, item := range listItems(block.Text) {
+				matches := statusItem.FindStringSubmatch(item)
+				if matches == nil {
+					warn(block.StartLine+i, "%s: cannot parse status %q", heading.Title, strings.TrimSpace(item))
+					continue
+				}
+				endpoint.Responses = mergeResponse(endpoint.Responses, Response{Status: matches[1], Description: strings.TrimSpace(matches[2])})
+			}
+			// Pointers into Responses may have moved
+			success = nil
+			for i := range endpoint.Responses {
+				if endpoint.Responses[i].Success() {
+					success = &endpoint.Responses[i]
+					break
+				}
+			}
+		}
+	}
+
+	if endpoint.Method == "" || endpoint.Path == "" {
+		warn(heading.StartLine, "%s: no HTTP method and URL found, section skipped", heading.Title)
+		return Endpoint{}, false
+	}
+
+	addPathParameters(&endpoint, func(name string) {
+		warn(heading.StartLine, "%s: path parameter %q does not appear in %s; ignored", heading.Title, name, endpoint.Path)
+	})
+	return endpoint, true
+}
+
+// splitLabels splits paragraphs at lines starting with a bold label, as
+// in "**Description:** ...\n**Request Body:**", so that each label starts
+// its own paragraph.
+func splitLabels(blocks []markdown.Block) []markdown.Block {
+	var split []markdown.Block
+	for _, block := range blocks {
+		if block.Kind != markdown.Paragraph {
+			split = append(split, block)
+			continue
+		}
+		lines := strings.Split(strings.TrimRight(block.Text, "\n"), "\n")
+		start := 0
+		for i := 1; i <= len(lines); i++ {
+			if i < len(lines) && !boldLabel.MatchString(strings.TrimSpace(lines[i])) {
+				continue
+			}
+			part := block
+			part.Text = strings.Join(lines[start:i], "\n") + "\n"
+			part.StartLine = block.StartLine + start
+			part.EndLine = block.StartLine + i - 1
+			split = append(split, part)
+			start = i
+		}
+	}
+	return split
+}
+
+// parseParameter reads a list item such as "`id` (string, required): The ID".
+func parseParameter(item string, section sectionKind) (Parameter, bool) {
+	matches := parameterItem.FindStringSubmatch(item)
+	if matches == nil {
+		return Parameter{}, false
+	}
+
+	param := Parameter{Name: matches[1], Description: strings.TrimSpace(matches[3])}
+	if param.Description == noDescription {
+		param.Description = ""
+	}
+	switch section {
+	case sectionPathParameters:
+		param.In = "path"
+	case sectionQueryParameters:
+		param.In = "query"
+	case sectionHeaderParameters:
+		param.In = "header"
+	case sectionCookieParameters:
+		param.In = "cookie"
+	}
+
+	for _, attribute := range strings.Split(matches[2], ",") {
+		attribute = strings.TrimSpace(attribute)
+		switch strings.ToLower(attribute) {
+		case "":
+		case "required":
+			param.Required = true
+		case "optional":
+		default:
+			if param.Type == "" {
+				param.Type = attribute
+			}
+		}
+	}
+	return param, true
+}
+
+// addPathParameters places parameters of unknown location in the path or
+// query and adds undocumented path parameters. Path parameters that do not
+// appear in the path are passed to unknown and dropped.
+func addPathParameters(endpoint *Endpoint, unknown func(name string)) {
+	inPath := make(map[string]bool)
+	for _, match := range pathParam.FindAllStringSubmatch(endpoint.Path, -1) {
+		inPath[match[1]] = true
+	}
+
+	parameters := endpoint.Parameters[:0]
+	documented := make(map[string]bool)
+	for _, param := range endpoint.Parameters {
+		if param.In == "" {
+			param.In = "query"
+			if inPath[param.Name] {
+				param.In = "path"
+			}
+		}
+		if param.In == "path" {
+			if !inPath[param.Name] {
+				unknown(param.Name)
+				continue
+			}
+			param.Required = true
+			documented[param.Name] = true
+		}
+		parameters = append(parameters, param)
+	}
+	endpoint.Parameters = parameters
+
+	for _, match := range pathParam.FindAllStringSubmatch(endpoint.Path, -1) {
+		if !documented[match[1]] {
+			endpoint.Parameters = append(endpoint.Parameters, Parameter{Name: match[1], In: "path", Type: "string", Required: true})
+			documented[match[1]] = true
+		}
+	}
+}
+
+// mergeResponse adds r, filling in the description of an existing
+// response with the same status instead of repeating it.
+func mergeResponse(responses []Response, r Response) []Response {
+	for i := range responses {
+		if responses[i].Status == r.Status {
+			if responses[i].Description == "" {
+				responses[i].Description = r.Description
+			}
+			return responses
+		}
+	}
+	return append(responses, r)
+}
+
+func checkJSON(block markdown.Block, contentType string, warn func(int, string, ...any), endpoint, what string) {
+	if !strings.Contains(contentType, "json") || !strings.HasPrefix(strings.TrimSpace(block.Text), "```json") {
+		return
+	}
+	if !json.Valid([]byte(fenceBody(block.Text))) {
+		warn(block.StartLine, "%s: %s example is not valid JSON; exported as a string", endpoint, what)
+	}
+}
+
+func statusOf(title, fallback string) string {
+	if match := statusPattern.FindString(title); match != "" {
+		return match
+	}
+	return fallback
+}
+
+// fenceBody returns the content of a fenced code block.
+func fenceBody(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) < 2 || len(strings.TrimSpace(lines[0])) < 3 {
+		return ""
+	}
+	marker := strings.TrimSpace(lines[0])[:3]
+	last := len(lines)
+	if strings.HasPrefix(strings.TrimSpace(lines[last-1]), marker) {
+		last--
+	}
+	return strings.Join(lines[1:last], "\n")
+}
+
+// listItems splits a list block into items, each with its continuation lines.
+func listItems(text string) []string {
+	var items []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if listItemStart.MatchString(line) || len(items) == 0 {
+			items = append(items, line)
+		} else if strings.TrimSpace(line) != "" {
+			items[len(items)-1] += " " + strings.TrimSpace(line)
+		}
+	}
+	return items
+}
+
+func httpMethod(method string) bool {
+	switch method {
+	case "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE":
+		return true
+	}
+	return false
+}
+
+func firstLine(text string) string {
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
+
+func restLines(text string) string {
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		return text[i+1:]
+	}
+	return ""
+}
+
+func joinText(existing, text string) string {
+	if existing == "" {
+		return text
+	}
+	return existing + "\n\n" + text
+}
+
+// ParseFiles reads files and returns the endpoints they document, keeping
+// the first of repeated endpoints, along with the parse warnings.
+func ParseFiles(files []string) ([]Endpoint, []Warning, error) {
+	var endpoints []Endpoint
+	var warnings []Warning
+	seen := make(map[string]string)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		found, fileWarnings := Parse(file, string(content))
+		warnings = append(warnings, fileWarnings...)
+		for _, endpoint := range found {
+			key := endpoint.Method + " " + NormalizePath(endpoint.Path)
+			if first, ok := seen[key]; ok {
+				warnings = append(warnings, Warning{File: file, Message: fmt.Sprintf("%s %s is already documented in %s; ignored", endpoint.Method, endpoint.Path, filepath.ToSlash(first))})
+				continue
+			}
+			seen[key] = file
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints, warnings, nil
+}
@@ -0,0 +1,61 @@
+package apispec
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParsePathParameters(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		want         []string
+		wantWarnings []string
+	}{
+		{
+			name:    "documented and undocumented path parameters",
+			content: "## GET /users/{userId}/posts/{postId}\n\n### Path Parameters\n\n- `userId` (string): The user\n",
+			want:    []string{"userId in path required", "postId in path required"},
+		},
+		{
+			name:    "parameters of unknown location",
+			content: "## GET /users/{id}\n\n### Parameters\n\n- `id` (string): The user\n- `limit` (integer): Page size\n",
+			want:    []string{"id in path required", "limit in query"},
+		},
+		{
+			name:         "path parameter missing from the path",
+			content:      "## GET /users\n\n### Path Parameters\n\n- `id` (string): The user\n\n### Query Parameters\n\n- `limit` (integer): Page size\n",
+			want:         []string{"limit in query"},
+			wantWarnings: []string{`api.md:1: GET /users: path parameter "id" does not appear in /users; ignored`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, warnings := Parse("api.md", tt.content)
+			if len(endpoints) != 1 {
+				t.Fatalf("got %d endpoints, want 1", len(endpoints))
+			}
+
+			var got []string
+			for _, param := range endpoints[0].Parameters {
+				desc := fmt.Sprintf("%s in %s", param.Name, param.In)
+				if param.Required {
+					desc += " required"
+				}
+				got = append(got, desc)
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("parameters = %v, want %v", got, tt.want)
+			}
+
+			var gotWarnings []string
+			for _, w := range warnings {
+				gotWarnings = append(gotWarnings, w.String())
+			}
+			if strings.Join(gotWarnings, "\n") != strings.Join(tt.wantWarnings, "\n") {
+				t.Errorf("warnings = %q, want %q", gotWarnings, tt.wantWarnings)
+			}
+		})
+	}
+}
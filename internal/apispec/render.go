@@ -0,0 +1,236 @@
+package apispec
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultBaseURL is used in examples when the API names no server.
+const DefaultBaseURL = "http://localhost:3000"
+
+var parameterSections = []struct {
+	in    string
+	title string
+}{
+	{"path", "Path Parameters"},
+	{"query", "Query Parameters"},
+	{"header", "Header Parameters"},
+	{"cookie", "Cookie Parameters"},
+}
+
+// noDescription stands in for missing descriptions.
+const noDescription = "No description."
+
+// RenderEndpoint renders an endpoint as a level-2 section following the
+// api template: overview, method and URL, parameters, responses and a
+// curl example.
+func RenderEndpoint(e Endpoint, baseURL string) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("## %s\n\n", e.Key()))
+
+	b.WriteString("### Overview\n")
+	overview := strings.TrimSpace(strings.Join(nonEmpty(e.Summary, e.Description), "\n\n"))
+	if overview == "" {
+		overview = noDescription
+	}
+	b.WriteString(overview + "\n")
+	if e.Deprecated {
+		b.WriteString("\n> **Deprecated:** this endpoint should no longer be used.\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("### HTTP Method and URL\n")
+	b.WriteString(fmt.Sprintf("```\n%s\n```\n\n", e.Key()))
+
+	b.WriteString("### Parameters\n")
+	if len(e.Parameters) == 0 && e.RequestBody == nil {
+		b.WriteString("None.\n")
+	}
+	for _, section := range parameterSections {
+		var lines []string
+		for _, p := range e.Parameters {
+			if p.In == section.in {
+				lines = append(lines, renderParameter(p))
+			}
+		}
+		if len(lines) > 0 {
+			b.WriteString(fmt.Sprintf("\n#### %s\n%s\n", section.title, strings.Join(lines, "\n")))
+		}
+	}
+	if body := e.RequestBody; body != nil {
+		b.WriteString("\n#### Request Body\n")
+		if body.Description != "" {
+			b.WriteString(body.Description + "\n\n")
+		}
+		b.WriteString(fmt.Sprintf("Content type: `%s`%s\n", body.ContentType, requiredSuffix(body.Required)))
+		if body.Example != "" {
+			b.WriteString(fmt.Sprintf("```%s\n%s\n```\n", fenceLanguage(body.ContentType), body.Example))
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString("### Response\n")
+	var errors []Response
+	var example *Response
+	for i, r := range e.Responses {
+		if !r.Success() {
+			errors = append(errors, r)
+			continue
+		}
+		if example == nil && r.Example != "" {
+			example = &e.Responses[i]
+		}
+		b.WriteString(fmt.Sprintf("\n#### Success Response (%s)\n", r.StatusLine()))
+		if r.Description != "" {
+			b.WriteString(r.Description + "\n")
+		}
+		if r.Example != "" {
+			b.WriteString(fmt.Sprintf("```%s\n%s\n```\n", fenceLanguage(r.ContentType), r.Example))
+		}
+	}
+	if len(errors) > 0 {
+		b.WriteString("\n#### Error Responses\n")
+		for _, r := range errors {
+			b.WriteString(fmt.Sprintf("- `%s`: %s\n", r.StatusLine(), oneLine(r.Description)))
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString("### Examples\n\n")
+	b.WriteString("#### Request\n")
+	b.WriteString(fmt.Sprintf("```bash\n%s\n```\n", curlExample(e, baseURL)))
+	if example != nil {
+		b.WriteString(fmt.Sprintf("\n#### Response\n```%s\n%s\n```\n", fenceLanguage(example.ContentType), example.Example))
+	}
+
+	return b.String()
+}
+
+func renderParameter(p Parameter) string {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	required := "optional"
+	if p.Required {
+		required = "required"
+	}
+	description := oneLine(p.Description)
+	if description == "" {
+		description = noDescription
+	}
+	return fmt.Sprintf("- `%s` (%s, %s): %s", p.Name, typ, required, description)
+}
+
+func requiredSuffix(required bool) string {
+	if required {
+		return " (required)"
+	}
+	return ""
+}
+
+// curlExample builds a request using example values for path parameters
+// and required query parameters.
+func curlExample(e Endpoint, baseURL string) string {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	path := e.Path
+	query := url.Values{}
+	var headers []string
+	for _, p := range e.Parameters {
+		switch p.In {
+		case "path":
+			if p.Example != "" {
+				path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(p.Example))
+			}
+		case "query":
+			if p.Required {
+				query.Set(p.Name, exampleOr(p.Example, "value"))
+			}
+		case "header":
+			if p.Required {
+				headers = append(headers, fmt.Sprintf("%s: %s", p.Name, exampleOr(p.Example, "value")))
+			}
+		}
+	}
+
+	target := strings.TrimRight(baseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	lines := []string{fmt.Sprintf(`curl -X %s "%s"`, e.Method, target)}
+	contentType := "application/json"
+	if e.RequestBody != nil && e.RequestBody.ContentType != "" {
+		contentType = e.RequestBody.ContentType
+	}
+	lines = append(lines, fmt.Sprintf(`  -H "Content-Type: %s"`, contentType))
+	for _, header := range headers {
+		lines = append(lines, fmt.Sprintf(`  -H "%s"`, header))
+	}
+	if e.RequestBody != nil && e.RequestBody.Example != "" {
+		body := compactJSON(e.RequestBody.Example)
+		lines = append(lines, fmt.Sprintf(`  -d '%s'`, strings.ReplaceAll(body, "'", `'\''`)))
+	}
+
+	return strings.Join(lines, " \\\n")
+}
+
+func exampleOr(example, fallback string) string {
+	if example != "" {
+		return example
+	}
+	return fallback
+}
+
+// compactJSON collapses an indented JSON example onto one line.
+func compactJSON(example string) string {
+	var b strings.Builder
+	inString := false
+	escaped := false
+	for _, r := range example {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inString:
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case !inString && (r == '\n' || r == ' ' || r == '\t' || r == '\r'):
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func fenceLanguage(contentType string) string {
+	switch {
+	case contentType == "", strings.Contains(contentType, "json"):
+		return "json"
+	case strings.Contains(contentType, "xml"):
+		return "xml"
+	case strings.Contains(contentType, "yaml"):
+		return "yaml"
+	default:
+		return ""
+	}
+}
+
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			result = append(result, strings.TrimSpace(value))
+		}
+	}
+	return result
+}
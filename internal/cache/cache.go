@@ -0,0 +1,126 @@
+// Package cache stores processed documents on disk so that unchanged inputs
+// do not have to be processed again on the next run.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache is a directory of entries keyed by document path and content hash.
+// It is safe for concurrent use.
+type Cache struct {
+	Dir string
+
+	mu      sync.Mutex
+	touched map[string]bool
+}
+
+// New returns a cache rooted at dir. The directory is created on the first Put.
+func New(dir string) *Cache {
+	return &Cache{
+		Dir:     dir,
+		touched: make(map[string]bool),
+	}
+}
+
+// Namespace returns a short, stable directory name for the given parts, so
+// that unrelated runs sharing a cache root do not evict each other's entries.
+func Namespace(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Key returns the cache key for the document at path with the given content.
+// options must describe every setting that influences how content is processed.
+func Key(path, options string, content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(options))
+	h.Write([]byte{0})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached value for key, if present.
+func (c *Cache) Get(key string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(c.Dir, key))
+	if err != nil {
+		return "", false
+	}
+
+	c.touch(key)
+	return string(data), true
+}
+
+// Put stores value under key. The entry is written to a temporary file and
+// renamed into place so that readers never observe a partial entry.
+func (c *Cache) Put(key, value string) error {
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.Dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+
+	if _, err := tmp.WriteString(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(c.Dir, key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	c.touch(key)
+	return nil
+}
+
+// Prune removes every entry that was neither read nor written since the
+// cache was opened.
+func (c *Cache) Prune() error {
+	entries, err := os.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range entries {
+		if entry.IsDir() || c.touched[entry.Name()] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.Dir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to prune cache entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Cache) touch(key string) {
+	c.mu.Lock()
+	c.touched[key] = true
+	c.mu.Unlock()
+}
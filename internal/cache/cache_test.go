@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetPut(t *testing.T) {
+	c := New(filepath.Join(t.TempDir(), "merge"))
+
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get of a missing key succeeded")
+	}
+	if err := c.Put("key", "processed"); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.Get("key"); !ok || got != "processed" {
+		t.Errorf("Get = %q, %t, want processed", got, ok)
+	}
+
+	// Entries outlive the Cache value
+	if got, ok := New(c.Dir).Get("key"); !ok || got != "processed" {
+		t.Errorf("Get from a new cache = %q, %t, want processed", got, ok)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "key" {
+		t.Errorf("cache directory holds %v", entries)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	first := New(dir)
+	for _, key := range []string{"read", "written", "stale"} {
+		if err := first.Put(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	second := New(dir)
+	second.Get("read")
+	if err := second.Put("written", "again"); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]bool{"read": true, "written": true, "stale": false} {
+		if _, err := os.Stat(filepath.Join(dir, key)); (err == nil) != want {
+			t.Errorf("%s kept = %t, want %t", key, err == nil, want)
+		}
+	}
+
+	if err := New(filepath.Join(dir, "missing")).Prune(); err != nil {
+		t.Errorf("Prune of a missing directory: %v", err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	base := Key("a.md", "v1", []byte("content"))
+	if Key("a.md", "v1", []byte("content")) != base {
+		t.Error("Key is not stable")
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		options string
+		content string
+	}{
+		{"path", "b.md", "v1", "content"},
+		{"options", "a.md", "v2", "content"},
+		{"content", "a.md", "v1", "changed"},
+		// Parts are separated, so moving bytes between them changes the key
+		{"boundary", "a.mdv", "1", "content"},
+	}
+	for _, tt := range tests {
+		if Key(tt.path, tt.options, []byte(tt.content)) == base {
+			t.Errorf("changing the %s does not change the key", tt.name)
+		}
+	}
+
+	if Namespace("docs", "out.md") == Namespace("docs", "other.md") {
+		t.Error("Namespace ignores its parts")
+	}
+	if Namespace("doc", "sout.md") == Namespace("docs", "out.md") {
+		t.Error("Namespace does not separate its parts")
+	}
+}
@@ -0,0 +1,316 @@
+// Package devlog keeps the dated entries of the debug log and the
+// improvement history: it adds entries in the format of the templates,
+// keeps them sorted newest first and moves old entries to an archive.
+package devlog
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+	"github.com/claude-code/claude-doc-structure/internal/section"
+)
+
+// DateFormat is the format of entry dates.
+const DateFormat = "2006-01-02"
+
+// placeholderDate marks the example entry of the templates.
+const placeholderDate = "YYYY-MM-DD"
+
+// Log describes a log file and the section holding its entries.
+type Log struct {
+	// File is the project-relative path of the log.
+	File string
+	// Section is the title of the level-2 section holding the entries.
+	Section string
+	// Labels lists the entry fields in the order they are written.
+	Labels []string
+	// ArchiveTitle is the document title of the archive file.
+	ArchiveTitle string
+}
+
+// Debug is .claude/debug-log.md.
+var Debug = Log{
+	File:         ".claude/debug-log.md",
+	Section:      "Critical Issues Resolved",
+	Labels:       []string{"Problem", "Root Cause", "Solution", "Prevention", "Files", "Commit"},
+	ArchiveTitle: "Debug Log Archive",
+}
+
+// Improvements is .claude/project-improvements.md.
+var Improvements = Log{
+	File:         ".claude/project-improvements.md",
+	Section:      "Major Milestones",
+	Labels:       []string{"Achievement", "Impact", "Files", "Commit"},
+	ArchiveTitle: "Project Improvement Archive",
+}
+
+// Entry is one dated log entry.
+type Entry struct {
+	Date  string
+	Title string
+	// Fields maps the log's labels to their values.
+	Fields map[string]string
+	// Items are listed under the fields, such as the changes an
+	// improvement made.
+	Items []string
+
+	// text is the source of an entry read from a log.
+	text string
+}
+
+// Markdown renders the entry as a level-3 section in the format of the
+// log's template.
+func (e Entry) Markdown(log Log) string {
+	if e.text != "" {
+		return e.text
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s: %s\n", e.Date, e.Title)
+	itemsWritten := false
+	writeItems := func() {
+		if !itemsWritten {
+			for _, item := range e.Items {
+				fmt.Fprintf(&b, "- ✅ %s\n", item)
+			}
+			itemsWritten = true
+		}
+	}
+
+	// Items follow the first field; the impact of an improvement closes
+	// the list
+	for i, label := range log.Labels {
+		value := strings.TrimSpace(e.Fields[label])
+		if label == "Impact" {
+			writeItems()
+			if value != "" {
+				fmt.Fprintf(&b, "- **Impact**: %s\n", value)
+			}
+			continue
+		}
+		if value != "" {
+			fmt.Fprintf(&b, "**%s**: %s\n", label, value)
+		}
+		if i == 0 {
+			writeItems()
+		}
+	}
+	writeItems()
+	return b.String()
+}
+
+// Validate checks that the entry has a title and a valid date.
+func (e Entry) Validate() error {
+	if strings.TrimSpace(e.Title) == "" {
+		return errors.New("entry needs a title")
+	}
+	if _, err := time.Parse(DateFormat, e.Date); err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", e.Date)
+	}
+	return nil
+}
+
+// Entries returns the entries of the log's section in content, in
+// document order, and the section they were read from.
+func Entries(content string, log Log) ([]Entry, section.Section, error) {
+	parent, err := section.Find(content, []string{"## " + log.Section})
+	if err != nil {
+		return nil, parent, err
+	}
+
+	var entries []Entry
+	for _, s := range section.All(content) {
+		if s.Start < parent.BodyStart || s.Start >= parent.End || s.Level != parent.Level+1 {
+			continue
+		}
+		entry := Entry{text: strings.TrimRight(content[s.Start:s.End], " \t\r\n") + "\n"}
+		if date, title, ok := strings.Cut(s.Title, ":"); ok {
+			entry.Date, entry.Title = strings.TrimSpace(date), strings.TrimSpace(title)
+		} else {
+			entry.Title = s.Title
+		}
+		entries = append(entries, entry)
+	}
+	return entries, parent, nil
+}
+
+// Add inserts entry into the log's section of content and sorts the
+// entries newest first. The template's example entry is dropped. A
+// missing section is created.
+func Add(content string, log Log, entry Entry) (string, error) {
+	if err := entry.Validate(); err != nil {
+		return "", err
+	}
+	entries, _, err := Entries(content, log)
+	if errors.Is(err, section.ErrNotFound) {
+		content, err = section.Create(content, []string{"## " + log.Section}, "")
+	}
+	if err != nil {
+		return "", err
+	}
+	return write(content, log, append([]Entry{entry}, entries...))
+}
+
+// Rotate removes the entries beyond the newest keep, and those dated
+// before the cutoff date, from the log's section and returns them. A keep
+// of 0 and an empty cutoff leave the log as it is.
+func Rotate(content string, log Log, keep int, cutoff string) (string, []Entry, error) {
+	entries, _, err := Entries(content, log)
+	if err != nil {
+		return "", nil, err
+	}
+	sortEntries(entries)
+
+	var kept, archived []Entry
+	for _, entry := range entries {
+		old := cutoff != "" && entry.Date != placeholderDate && dated(entry) && entry.Date < cutoff
+		if old || (keep > 0 && len(kept) >= keep) {
+			archived = append(archived, entry)
+		} else {
+			kept = append(kept, entry)
+		}
+	}
+	if len(archived) == 0 {
+		return content, nil, nil
+	}
+	content, err = write(content, log, kept)
+	return content, archived, err
+}
+
+// Archive adds entries to the archive content, creating it when empty.
+func Archive(archive string, log Log, entries []Entry) (string, error) {
+	if strings.TrimSpace(archive) == "" {
+		archive = fmt.Sprintf("# %s\n\nEntries moved from %s.\n\n## %s\n", log.ArchiveTitle, log.File, log.Section)
+	}
+	existing, _, err := Entries(archive, log)
+	if errors.Is(err, section.ErrNotFound) {
+		archive, err = section.Create(archive, []string{"## " + log.Section}, "")
+	}
+	if err != nil {
+		return "", err
+	}
+	return write(archive, log, append(entries, existing...))
+}
+
+// write replaces the entries of the log's section, keeping the text
+// before the first entry.
+func write(content string, log Log, entries []Entry) (string, error) {
+	parent, err := section.Find(content, []string{"## " + log.Section})
+	if err != nil {
+		return "", err
+	}
+
+	// Keep any introduction that precedes the entries
+	intro := content[parent.BodyStart:parent.End]
+	for _, s := range section.All(content) {
+		if s.Start >= parent.BodyStart && s.Start < parent.End && s.Level == parent.Level+1 {
+			intro = content[parent.BodyStart:s.Start]
+			break
+		}
+	}
+	intro = strings.TrimRight(intro, " \t\r\n")
+
+	var kept []Entry
+	for _, entry := range entries {
+		if entry.Date != placeholderDate {
+			kept = append(kept, entry)
+		}
+	}
+	sortEntries(kept)
+
+	var b strings.Builder
+	if intro != "" {
+		b.WriteString("\n" + strings.TrimLeft(intro, "\r\n") + "\n")
+	}
+	for _, entry := range kept {
+		b.WriteString("\n" + entry.Markdown(log))
+	}
+	if parent.End < len(content) {
+		b.WriteString("\n")
+	}
+	return content[:parent.BodyStart] + b.String() + content[parent.End:], nil
+}
+
+// sortEntries orders dated entries newest first and keeps undated ones
+// after them in their order. Entries of the same day keep their order, so
+// an entry added first stays above earlier entries of its day.
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		di, dj := dated(entries[i]), dated(entries[j])
+		if di != dj {
+			return di
+		}
+		return di && entries[i].Date > entries[j].Date
+	})
+}
+
+func dated(entry Entry) bool {
+	_, err := time.Parse(DateFormat, entry.Date)
+	return err == nil
+}
+
+// ParseFields reads entry fields from "Label: value" lines, as written by
+// hand or by a script. Labels match the log's labels ignoring case and
+// bold markers; "Title" and "Date" set the entry's title and date, and
+// list items are collected as items. Lines without a label continue the
+// previous field.
+func ParseFields(text string, log Log, entry *Entry) {
+	if entry.Fields == nil {
+		entry.Fields = make(map[string]string)
+	}
+	current := ""
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if _, _, ok := markdown.ParseHeading(trimmed); ok {
+			continue
+		}
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok {
+			item = strings.TrimSpace(strings.TrimPrefix(item, "✅"))
+			if label, value, ok := labelled(item, log); ok && label == "Impact" {
+				entry.Fields[label] = value
+			} else {
+				entry.Items = append(entry.Items, item)
+			}
+			continue
+		}
+		if label, value, ok := labelled(trimmed, log); ok {
+			switch label {
+			case "Title":
+				entry.Title = value
+			case "Date":
+				entry.Date = value
+			default:
+				entry.Fields[label] = value
+				current = label
+			}
+			continue
+		}
+		if current != "" {
+			entry.Fields[current] = strings.TrimSpace(entry.Fields[current] + " " + trimmed)
+		}
+	}
+}
+
+// labelled splits a "Label: value" line whose label is one of the log's
+// labels, Title or Date.
+func labelled(line string, log Log) (string, string, bool) {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.TrimSpace(strings.Trim(strings.TrimSpace(key), "*"))
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "**"))
+	for _, label := range append([]string{"Title", "Date"}, log.Labels...) {
+		if strings.EqualFold(key, label) {
+			return label, value, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,135 @@
+package devlog
+
+import (
+	"strings"
+	"testing"
+)
+
+const debugLog = `# Debug Log
+
+## Critical Issues Resolved
+
+### YYYY-MM-DD: Example Issue
+**Problem**: Example
+
+## Common Issues
+`
+
+func titles(t *testing.T, content string) []string {
+	t.Helper()
+
+	entries, _, err := Entries(content, Debug)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, entry := range entries {
+		titles = append(titles, entry.Date+" "+entry.Title)
+	}
+	return titles
+}
+
+func TestAddAndRotate(t *testing.T) {
+	content := debugLog
+	for _, date := range []string{"2026-02-01", "2026-01-01", "2026-03-01"} {
+		var err error
+		content, err = Add(content, Debug, Entry{Date: date, Title: "Issue " + date, Fields: map[string]string{"Problem": "p"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Newest first, without the template's example entry
+	want := "2026-03-01 Issue 2026-03-01,2026-02-01 Issue 2026-02-01,2026-01-01 Issue 2026-01-01"
+	if got := strings.Join(titles(t, content), ","); got != want {
+		t.Fatalf("entries = %s, want %s", got, want)
+	}
+	if !strings.Contains(content, "## Common Issues") {
+		t.Errorf("the following section was lost:\n%s", content)
+	}
+
+	tests := []struct {
+		name         string
+		keep         int
+		cutoff       string
+		wantKept     string
+		wantArchived string
+	}{
+		{"nothing to rotate", 0, "", want, ""},
+		{"keep newest", 1, "", "2026-03-01 Issue 2026-03-01", "2026-02-01 Issue 2026-02-01,2026-01-01 Issue 2026-01-01"},
+		{"cutoff", 0, "2026-02-01", "2026-03-01 Issue 2026-03-01,2026-02-01 Issue 2026-02-01", "2026-01-01 Issue 2026-01-01"},
+		{"keep and cutoff", 2, "2026-03-01", "2026-03-01 Issue 2026-03-01", "2026-02-01 Issue 2026-02-01,2026-01-01 Issue 2026-01-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rotated, archived, err := Rotate(content, Debug, tt.keep, tt.cutoff)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(titles(t, rotated), ","); got != tt.wantKept {
+				t.Errorf("kept = %s, want %s", got, tt.wantKept)
+			}
+
+			archive, err := Archive("", Debug, archived)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(titles(t, archive), ","); got != tt.wantArchived {
+				t.Errorf("archived = %s, want %s", got, tt.wantArchived)
+			}
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		log        Log
+		text       string
+		wantTitle  string
+		wantFields map[string]string
+		wantItems  []string
+	}{
+		{
+			name:       "debug fields",
+			log:        Debug,
+			text:       "Title: Login loop\n**Problem**: Login fails\nafter refresh\nroot cause: stale token\n",
+			wantTitle:  "Login loop",
+			wantFields: map[string]string{"Problem": "Login fails after refresh", "Root Cause": "stale token"},
+		},
+		{
+			name:       "improvement items and impact",
+			log:        Improvements,
+			text:       "### 2026-05-01: ignored heading\nAchievement: Faster CI\n- ✅ Cached dependencies\n- Parallel tests\n- **Impact**: 4 minute builds\n",
+			wantFields: map[string]string{"Achievement": "Faster CI", "Impact": "4 minute builds"},
+			wantItems:  []string{"Cached dependencies", "Parallel tests"},
+		},
+		{
+			name:       "labels of other logs are text",
+			log:        Improvements,
+			text:       "Achievement: Faster CI\nProblem: none\n",
+			wantFields: map[string]string{"Achievement": "Faster CI Problem: none"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entry Entry
+			ParseFields(tt.text, tt.log, &entry)
+
+			if entry.Title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", entry.Title, tt.wantTitle)
+			}
+			if len(entry.Fields) != len(tt.wantFields) {
+				t.Errorf("fields = %v, want %v", entry.Fields, tt.wantFields)
+			}
+			for label, want := range tt.wantFields {
+				if got := entry.Fields[label]; got != want {
+					t.Errorf("%s = %q, want %q", label, got, want)
+				}
+			}
+			if strings.Join(entry.Items, ",") != strings.Join(tt.wantItems, ",") {
+				t.Errorf("items = %v, want %v", entry.Items, tt.wantItems)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Package events defines the progress events reported by the document
+// processing packages in place of printing.
+package events
+
+type Kind string
+
+const (
+	// FileWritten reports that Path was created or, with Replaced set,
+	// replaced.
+	FileWritten Kind = "file-written"
+	// FileUnchanged reports that Path already had the generated content.
+	FileUnchanged Kind = "file-unchanged"
+	// FileRemoved reports that Path, generated by an earlier run, was deleted.
+	FileRemoved Kind = "file-removed"
+	// FileConflict reports that Path was edited since it was generated and
+	// was left as it is.
+	FileConflict Kind = "file-conflict"
+	// FileKept reports that Path was edited since it was generated and was
+	// left as it is, since its generated content did not change either.
+	FileKept Kind = "file-kept"
+	// DocumentMerged reports that the document at Path was written to the
+	// merged output. Cached is set when its processed content came from the cache.
+	DocumentMerged Kind = "document-merged"
+	// SplitFinished reports that Count oversized documents were split, with
+	// their manifest written to the directory Path.
+	SplitFinished Kind = "split-finished"
+	// MergeFinished reports that Count documents were merged into Path,
+	// which is "-" for standard output.
+	MergeFinished Kind = "merge-finished"
+)
+
+type Event struct {
+	Kind     Kind
+	Path     string
+	Count    int
+	Cached   bool
+	Replaced bool
+}
+
+// Handler receives events. A nil Handler discards them.
+type Handler func(Event)
+
+// Emit calls h with e if h is not nil.
+func (h Handler) Emit(e Event) {
+	if h != nil {
+		h(e)
+	}
+}
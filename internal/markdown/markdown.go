@@ -0,0 +1,233 @@
+// Package markdown splits Markdown documents into top-level blocks such as
+// headings, paragraphs, fenced code, tables and lists, keeping the exact
+// source position of each block.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+type BlockKind int
+
+const (
+	Paragraph BlockKind = iota
+	Heading
+	Fence
+	Table
+	List
+	Quote
+)
+
+type Block struct {
+	Kind BlockKind
+	// Text is the exact source of the block, including its final newline.
+	Text string
+	// Level and Title are set for headings.
+	Level int
+	Title string
+	// Start and End are byte offsets into the source; StartLine and
+	// EndLine are 1-based and inclusive.
+	Start     int
+	End       int
+	StartLine int
+	EndLine   int
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	fencePattern   = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+)
+
+// ListItemPattern matches the marker that starts a bulleted or numbered
+// list item, including its indentation and the whitespace after it.
+var ListItemPattern = regexp.MustCompile(`^\s*([-*+]|\d+[.)])\s+`)
+
+type line struct {
+	text  string
+	start int
+	end   int
+}
+
+func splitLines(content string) []line {
+	var lines []line
+	for start := 0; start < len(content); {
+		end := strings.IndexByte(content[start:], '\n')
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += start + 1
+		}
+		lines = append(lines, line{
+			text:  strings.TrimRight(content[start:end], "\r\n"),
+			start: start,
+			end:   end,
+		})
+		start = end
+	}
+	return lines
+}
+
+func isBlank(text string) bool {
+	return strings.TrimSpace(text) == ""
+}
+
+// ParseHeading returns the level and title of an ATX heading line.
+func ParseHeading(text string) (int, string, bool) {
+	matches := headingPattern.FindStringSubmatch(text)
+	if matches == nil {
+		return 0, "", false
+	}
+	return len(matches[1]), strings.TrimSpace(matches[2]), true
+}
+
+func isTableLine(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), "|")
+}
+
+func isQuoteLine(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), ">")
+}
+
+func isIndented(text string) bool {
+	return strings.HasPrefix(text, "  ") || strings.HasPrefix(text, "\t")
+}
+
+// startsBlock reports whether a line interrupts a paragraph.
+func startsBlock(text string) bool {
+	if _, _, ok := ParseHeading(text); ok {
+		return true
+	}
+	return fencePattern.MatchString(text) || isTableLine(text) || isQuoteLine(text) || ListItemPattern.MatchString(text)
+}
+
+// Blocks parses content into its top-level blocks. Blank lines between
+// blocks belong to no block. Fenced code is never split, even when it
+// contains lines that look like headings.
+func Blocks(content string) []Block {
+	lines := splitLines(content)
+	var blocks []Block
+
+	emit := func(kind BlockKind, from, to int) {
+		block := Block{
+			Kind:      kind,
+			Text:      content[lines[from].start:lines[to].end],
+			Start:     lines[from].start,
+			End:       lines[to].end,
+			StartLine: from + 1,
+			EndLine:   to + 1,
+		}
+		if kind == Heading {
+			block.Level, block.Title, _ = ParseHeading(lines[from].text)
+		}
+		blocks = append(blocks, block)
+	}
+
+	for i := 0; i < len(lines); {
+		text := lines[i].text
+
+		switch {
+		case isBlank(text):
+			i++
+
+		case fencePattern.MatchString(text):
+			marker := fencePattern.FindStringSubmatch(text)[1]
+			end := len(lines) - 1
+			for j := i + 1; j < len(lines); j++ {
+				trimmed := strings.TrimSpace(lines[j].text)
+				if strings.HasPrefix(trimmed, marker) && strings.Trim(trimmed, marker[:1]) == "" {
+					end = j
+					break
+				}
+			}
+			emit(Fence, i, end)
+			i = end + 1
+
+		case headingPattern.MatchString(text):
+			emit(Heading, i, i)
+			i++
+
+		case isTableLine(text):
+			end := i
+			for end+1 < len(lines) && isTableLine(lines[end+1].text) {
+				end++
+			}
+			emit(Table, i, end)
+			i = end + 1
+
+		case isQuoteLine(text):
+			end := i
+			for end+1 < len(lines) && !isBlank(lines[end+1].text) {
+				end++
+			}
+			emit(Quote, i, end)
+			i = end + 1
+
+		case ListItemPattern.MatchString(text):
+			// A list continues through items, indented continuation lines
+			// and blank lines that are followed by more of the list.
+			end := i
+			for j := i + 1; j < len(lines); j++ {
+				next := lines[j].text
+				if isBlank(next) {
+					continue
+				}
+				if ListItemPattern.MatchString(next) || isIndented(next) {
+					end = j
+					continue
+				}
+				if isBlank(lines[j-1].text) {
+					break
+				}
+				// Lazy continuation of the previous item's paragraph
+				if startsBlock(next) {
+					break
+				}
+				end = j
+			}
+			emit(List, i, end)
+			i = end + 1
+
+		default:
+			end := i
+			for end+1 < len(lines) && !isBlank(lines[end+1].text) && !startsBlock(lines[end+1].text) {
+				end++
+			}
+			emit(Paragraph, i, end)
+			i = end + 1
+		}
+	}
+
+	return blocks
+}
+
+// HeadingPath tracks the titles of the headings enclosing a position in a
+// document as blocks are visited in order.
+type HeadingPath struct {
+	levels []int
+	titles []string
+}
+
+// Enter records a heading, replacing any headings at the same or a deeper level.
+func (p *HeadingPath) Enter(level int, title string) {
+	for len(p.levels) > 0 && p.levels[len(p.levels)-1] >= level {
+		p.levels = p.levels[:len(p.levels)-1]
+		p.titles = p.titles[:len(p.titles)-1]
+	}
+	p.levels = append(p.levels, level)
+	p.titles = append(p.titles, title)
+}
+
+// Titles returns a copy of the current heading titles, outermost first.
+func (p *HeadingPath) Titles() []string {
+	return append([]string(nil), p.titles...)
+}
+
+// EstimateTokens approximates the number of model tokens in text, using
+// the common rule of thumb of four characters per token.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return (len(text) + 3) / 4
+}
@@ -1,13 +1,28 @@
 package merger
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/claude-code/claude-doc-structure/internal/cache"
+	"github.com/claude-code/claude-doc-structure/internal/events"
+)
+
+const mergedHeader = "# Merged Documentation\n\n"
+
+var (
+	anchorStrip = regexp.MustCompile(`[^a-z0-9-]`)
+	linkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
 )
 
 type Merger struct {
@@ -15,20 +30,27 @@ type Merger struct {
 	OutputFile        string
 	Pattern           string
 	Exclude           []string
+	Skip              []string
 	Recursive         bool
 	AddTOC            bool
 	AddDividers       bool
 	PreserveStructure bool
 	AddSummary        bool
 	OptimizeForClaude bool
+	CacheDir          string
+	Jobs              int
+	OnEvent           events.Handler
 }
 
+// Document describes an input document. Its content is only read, through
+// Open, while the document is being written, so memory use does not grow
+// with the corpus.
 type Document struct {
 	Filename string
 	Path     string
-	Content  string
 	Size     int64
 	ModTime  time.Time
+	Open     func() (io.ReadCloser, error)
 }
 
 func New(inputDir, outputFile string) *Merger {
@@ -43,24 +65,40 @@ func New(inputDir, outputFile string) *Merger {
 		PreserveStructure: true,
 		AddSummary:        true,
 		OptimizeForClaude: true,
+		Jobs:              runtime.NumCPU(),
 	}
 }
 
-func (m *Merger) Merge() error {
+// IsMergedOutput reports whether path holds the output of an earlier merge.
+func IsMergedOutput(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header := make([]byte, len(mergedHeader))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+	return string(header) == mergedHeader
+}
+
+// Documents returns the files found in InputDir, sorted by filename.
+func (m *Merger) Documents() ([]Document, error) {
 	// Find all matching files
 	files, err := m.findFiles()
 	if err != nil {
-		return fmt.Errorf("failed to find files: %w", err)
+		return nil, fmt.Errorf("failed to find files: %w", err)
 	}
 
 	if len(files) == 0 {
-		return fmt.Errorf("no files found matching pattern %s", m.Pattern)
+		return nil, fmt.Errorf("no files found matching pattern %s", m.Pattern)
 	}
 
-	// Read and process documents
-	documents, err := m.readDocuments(files)
+	documents, err := m.statDocuments(files)
 	if err != nil {
-		return fmt.Errorf("failed to read documents: %w", err)
+		return nil, fmt.Errorf("failed to read documents: %w", err)
 	}
 
 	// Sort documents by filename
@@ -68,16 +106,40 @@ func (m *Merger) Merge() error {
 		return documents[i].Filename < documents[j].Filename
 	})
 
-	// Generate merged content
-	content := m.generateMergedContent(documents)
+	return documents, nil
+}
 
-	// Write output file
-	err = os.WriteFile(m.OutputFile, []byte(content), 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write output file: %w", err)
+// RenderDocuments writes the given documents to w in order. The table of
+// contents and summary are built from document metadata alone; content is
+// then read, processed and written one document at a time, with at most
+// Jobs documents held in memory.
+func (m *Merger) RenderDocuments(ctx context.Context, w io.Writer, documents []Document) error {
+	if len(documents) == 0 {
+		return fmt.Errorf("no documents to merge")
+	}
+
+	var c *cache.Cache
+	if m.CacheDir != "" {
+		c = cache.New(filepath.Join(m.CacheDir, "merge", cache.Namespace(m.InputDir, m.OutputFile)))
+	}
+
+	out := bufio.NewWriter(w)
+	m.writeHeader(out, documents)
+
+	if err := m.writeDocuments(ctx, out, documents, c); err != nil {
+		return err
+	}
+
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if c != nil {
+		if err := c.Prune(); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("Merged %d documents into %s\n", len(documents), m.OutputFile)
 	return nil
 }
 
@@ -120,7 +182,35 @@ func (m *Merger) findFiles() ([]string, error) {
 	return files, nil
 }
 
+// Matches reports whether path would be merged as an input document.
+func (m *Merger) Matches(path string) bool {
+	if matched, _ := filepath.Match(m.Pattern, filepath.Base(path)); !matched {
+		return false
+	}
+	if m.isExcluded(path) {
+		return false
+	}
+
+	if m.Recursive {
+		rel, err := filepath.Rel(m.InputDir, path)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+	return samePath(filepath.Dir(path), m.InputDir)
+}
+
+// isExcluded reports whether path is the output file, lies in one of the
+// Skip files or directories, or matches an Exclude pattern.
 func (m *Merger) isExcluded(path string) bool {
+	// Never merge the output of a previous run back into itself
+	if samePath(path, m.OutputFile) {
+		return true
+	}
+	for _, skip := range m.Skip {
+		if within(path, skip) {
+			return true
+		}
+	}
+
 	filename := filepath.Base(path)
 	for _, exclude := range m.Exclude {
 		if matched, _ := filepath.Match(exclude, filename); matched {
@@ -130,82 +220,166 @@ func (m *Merger) isExcluded(path string) bool {
 	return false
 }
 
-func (m *Merger) readDocuments(files []string) ([]Document, error) {
-	var documents []Document
+// statDocuments collects the metadata of every file with a single stat per file.
+func (m *Merger) statDocuments(files []string) ([]Document, error) {
+	documents := make([]Document, 0, len(files))
 
 	for _, file := range files {
-		content, err := os.ReadFile(file)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read file %s: %w", file, err)
-		}
-
+		file := file // each Open must read its own file
 		info, err := os.Stat(file)
 		if err != nil {
 			return nil, fmt.Errorf("failed to stat file %s: %w", file, err)
 		}
 
-		doc := Document{
+		documents = append(documents, Document{
 			Filename: filepath.Base(file),
 			Path:     file,
-			Content:  string(content),
 			Size:     info.Size(),
 			ModTime:  info.ModTime(),
-		}
-
-		documents = append(documents, doc)
+			Open: func() (io.ReadCloser, error) {
+				return os.Open(file)
+			},
+		})
 	}
 
 	return documents, nil
 }
 
-func (m *Merger) generateMergedContent(documents []Document) string {
-	var content strings.Builder
-
-	// Add header
-	content.WriteString(fmt.Sprintf("# Merged Documentation\n\n"))
-	content.WriteString(fmt.Sprintf("Generated on: %s\n", time.Now().Format("2006-01-02 15:04:05")))
-	content.WriteString(fmt.Sprintf("Source directory: %s\n", m.InputDir))
-	content.WriteString(fmt.Sprintf("Total documents: %d\n\n", len(documents)))
+type processResult struct {
+	content string
+	cached  bool
+	err     error
+}
 
-	// Add table of contents
-	if m.AddTOC {
-		content.WriteString(m.generateTOC(documents))
+// writeDocuments reads and processes documents on up to Jobs workers and
+// writes them in order. A worker slot is only released once its document
+// has been written, which bounds the number of documents held in memory.
+func (m *Merger) writeDocuments(ctx context.Context, w io.Writer, documents []Document, c *cache.Cache) error {
+	jobs := m.Jobs
+	if jobs < 1 {
+		jobs = 1
 	}
 
-	// Add summary
-	if m.AddSummary {
-		content.WriteString(m.generateSummary(documents))
+	results := make([]chan processResult, len(documents))
+	for i := range results {
+		results[i] = make(chan processResult, 1)
 	}
 
-	// Add Claude optimization section
-	if m.OptimizeForClaude {
-		content.WriteString(m.generateClaudeOptimization(documents))
-	}
+	slots := make(chan struct{}, jobs)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for i := range documents {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			case <-ctx.Done():
+				results[i] <- processResult{err: ctx.Err()}
+				return
+			}
+
+			go func(i int) {
+				content, cached, err := m.loadDocument(c, documents[i])
+				results[i] <- processResult{content: content, cached: cached, err: err}
+			}(i)
+		}
+	}()
 
-	// Add document contents
 	for i, doc := range documents {
+		result := <-results[i]
+		if result.err != nil {
+			return fmt.Errorf("failed to read documents: %w", result.err)
+		}
+
 		if m.AddDividers {
-			content.WriteString("---\n\n")
+			fmt.Fprint(w, "---\n\n")
 		}
 
-		content.WriteString(fmt.Sprintf("## Document: %s\n\n", doc.Filename))
-		
+		fmt.Fprintf(w, "## Document: %s\n\n", doc.Filename)
+
 		if m.PreserveStructure {
-			content.WriteString(fmt.Sprintf("**File:** `%s`\n", doc.Path))
-			content.WriteString(fmt.Sprintf("**Size:** %d bytes\n", doc.Size))
-			content.WriteString(fmt.Sprintf("**Modified:** %s\n\n", doc.ModTime.Format("2006-01-02 15:04:05")))
+			fmt.Fprintf(w, "**File:** `%s`\n", doc.Path)
+			fmt.Fprintf(w, "**Size:** %d bytes\n", doc.Size)
+			fmt.Fprintf(w, "**Modified:** %s\n\n", doc.ModTime.Format("2006-01-02 15:04:05"))
 		}
 
-		// Process content
-		processedContent := m.processContent(doc.Content)
-		content.WriteString(processedContent)
+		io.WriteString(w, result.content)
 
 		if i < len(documents)-1 {
-			content.WriteString("\n\n")
+			io.WriteString(w, "\n\n")
 		}
+
+		m.OnEvent.Emit(events.Event{Kind: events.DocumentMerged, Path: doc.Path, Cached: result.cached})
+		<-slots
+	}
+
+	return nil
+}
+
+// loadDocument reads a document and returns its processed content and
+// whether it came from the cache.
+func (m *Merger) loadDocument(c *cache.Cache, doc Document) (string, bool, error) {
+	f, err := doc.Open()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read file %s: %w", doc.Path, err)
+	}
+	defer f.Close()
+
+	buf := bytes.NewBuffer(make([]byte, 0, doc.Size+bytes.MinRead))
+	if _, err := buf.ReadFrom(f); err != nil {
+		return "", false, fmt.Errorf("failed to read file %s: %w", doc.Path, err)
+	}
+
+	content, cached := m.processCached(c, doc.Path, buf.Bytes())
+	return content, cached, nil
+}
+
+// processCached returns the processed form of content, reusing the cached
+// result when the same file with the same content was processed before.
+func (m *Merger) processCached(c *cache.Cache, path string, content []byte) (string, bool) {
+	if c == nil {
+		return m.processContent(string(content)), false
+	}
+
+	key := cache.Key(path, m.processOptions(), content)
+	if processed, ok := c.Get(key); ok {
+		return processed, true
+	}
+
+	processed := m.processContent(string(content))
+	// A failed cache write only costs a re-process on the next run.
+	_ = c.Put(key, processed)
+	return processed, false
+}
+
+// processOptions describes the settings that influence processContent.
+func (m *Merger) processOptions() string {
+	return fmt.Sprintf("v1 structure=%t input=%s", m.PreserveStructure, m.InputDir)
+}
+
+// writeHeader writes everything that precedes the first document.
+func (m *Merger) writeHeader(w io.Writer, documents []Document) {
+	fmt.Fprint(w, mergedHeader)
+	fmt.Fprintf(w, "Generated on: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "Source directory: %s\n", m.InputDir)
+	fmt.Fprintf(w, "Total documents: %d\n\n", len(documents))
+
+	// Add table of contents
+	if m.AddTOC {
+		io.WriteString(w, m.generateTOC(documents))
 	}
 
-	return content.String()
+	// Add summary
+	if m.AddSummary {
+		io.WriteString(w, m.generateSummary(documents))
+	}
+
+	// Add Claude optimization section
+	if m.OptimizeForClaude {
+		io.WriteString(w, m.generateClaudeOptimization(documents))
+	}
 }
 
 func (m *Merger) generateTOC(documents []Document) string {
@@ -215,7 +389,7 @@ func (m *Merger) generateTOC(documents []Document) string {
 	
 	for i, doc := range documents {
 		anchor := strings.ToLower(strings.ReplaceAll(doc.Filename, " ", "-"))
-		anchor = regexp.MustCompile(`[^a-z0-9-]`).ReplaceAllString(anchor, "")
+		anchor = anchorStrip.ReplaceAllString(anchor, "")
 		
 		toc.WriteString(fmt.Sprintf("%d. [%s](#document-%s)\n", i+1, doc.Filename, anchor))
 	}
@@ -313,36 +487,94 @@ func (m *Merger) processContent(content string) string {
 }
 
 func (m *Merger) processLinks(content string) string {
-	// Update relative markdown links to be absolute
-	linkPattern := regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
-	
-	return linkPattern.ReplaceAllStringFunc(content, func(match string) string {
-		parts := linkPattern.FindStringSubmatch(match)
-		if len(parts) != 3 {
-			return match
+	// Update relative markdown links to be absolute, matching each link once
+	matches := linkPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+
+	var b strings.Builder
+	b.Grow(len(content))
+	last := 0
+	for _, match := range matches {
+		text := content[match[2]:match[3]]
+		link := content[match[4]:match[5]]
+
+		// Skip absolute URLs, anchors and absolute paths
+		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") ||
+			strings.HasPrefix(link, "#") || filepath.IsAbs(link) {
+			continue
 		}
-		
-		text := parts[1]
-		link := parts[2]
-		
-		// Skip absolute URLs
-		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
-			return match
+
+		b.WriteString(content[last:match[0]])
+		fmt.Fprintf(&b, "[%s](%s)", text, filepath.Join(m.InputDir, link))
+		last = match[1]
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+// within reports whether path is root or lies under it.
+func within(path, root string) bool {
+	absPath, errPath := filepath.Abs(path)
+	absRoot, errRoot := filepath.Abs(root)
+	if errPath != nil || errRoot != nil {
+		absPath, absRoot = filepath.Clean(path), filepath.Clean(root)
+	}
+	rel, err := filepath.Rel(absRoot, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
+// EqualIgnoringVolatileLines compares two merged outputs line by line,
+// skipping the lines that differ between otherwise identical runs.
+func EqualIgnoringVolatileLines(a, b io.Reader) (bool, error) {
+	scannerA := newLineScanner(a)
+	scannerB := newLineScanner(b)
+
+	for {
+		lineA, okA := nextStableLine(scannerA)
+		lineB, okB := nextStableLine(scannerB)
+
+		if !okA || !okB {
+			if err := scannerA.Err(); err != nil {
+				return false, fmt.Errorf("failed to read output file: %w", err)
+			}
+			if err := scannerB.Err(); err != nil {
+				return false, err
+			}
+			return okA == okB, nil
 		}
-		
-		// Skip anchors
-		if strings.HasPrefix(link, "#") {
-			return match
+
+		if lineA != lineB {
+			return false, nil
 		}
-		
-		// Convert relative paths to absolute
-		if !filepath.IsAbs(link) {
-			absLink := filepath.Join(m.InputDir, link)
-			return fmt.Sprintf("[%s](%s)", text, absLink)
+	}
+}
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return scanner
+}
+
+func nextStableLine(scanner *bufio.Scanner) (string, bool) {
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "Generated on: ") || strings.HasPrefix(line, "**Modified:** ") {
+			continue
 		}
-		
-		return match
-	})
+		return line, true
+	}
+	return "", false
 }
 
 func formatBytes(bytes int64) string {
@@ -0,0 +1,61 @@
+package merger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeCorpus(b *testing.B, files int) []string {
+	b.Helper()
+
+	dir := b.TempDir()
+	var body strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&body, "## Section %d\n\nSee [the guide](guide-%d.md) and [the docs](https://example.com/%d).\n\n", i, i, i)
+	}
+
+	paths := make([]string, files)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("doc-%04d.md", i))
+		content := fmt.Sprintf("---\ntitle: Doc %d\n---\n# Doc %d\n\n%s", i, i, body.String())
+		if err := os.WriteFile(paths[i], []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func benchmarkRender(b *testing.B, jobs int) {
+	files := writeCorpus(b, 2000)
+	m := New(filepath.Dir(files[0]), filepath.Join(b.TempDir(), "merged.md"))
+	m.Jobs = jobs
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		documents, err := m.Documents()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := m.RenderDocuments(context.Background(), io.Discard, documents); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRenderSequential(b *testing.B) {
+	benchmarkRender(b, 1)
+}
+
+func BenchmarkRender4Jobs(b *testing.B) {
+	benchmarkRender(b, 4)
+}
+
+func BenchmarkRenderAllCPUs(b *testing.B) {
+	benchmarkRender(b, runtime.NumCPU())
+}
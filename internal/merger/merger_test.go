@@ -0,0 +1,221 @@
+package merger
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func render(t *testing.T, m *Merger) string {
+	t.Helper()
+
+	documents, err := m.Documents()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := m.RenderDocuments(context.Background(), &buf, documents); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// documentBodies returns the content written under each "## Document:"
+// heading of a merged output.
+func documentBodies(t *testing.T, merged string) map[string]string {
+	t.Helper()
+
+	bodies := make(map[string]string)
+	parts := strings.Split(merged, "## Document: ")
+	for _, part := range parts[1:] {
+		name, body, _ := strings.Cut(part, "\n")
+		bodies[name] = body
+	}
+	return bodies
+}
+
+func TestRenderKeepsEachDocumentsContent(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.md": "# Alpha\n\nalpha content\n",
+		"b.md": "# Beta\n\nbeta content\n",
+	})
+
+	for _, jobs := range []int{1, 4} {
+		m := New(dir, filepath.Join(t.TempDir(), "merged.md"))
+		m.Jobs = jobs
+		bodies := documentBodies(t, render(t, m))
+
+		if len(bodies) != 2 {
+			t.Fatalf("jobs=%d: got %d documents, want 2", jobs, len(bodies))
+		}
+		if body := bodies["a.md"]; !strings.Contains(body, "alpha content") || strings.Contains(body, "beta content") {
+			t.Errorf("jobs=%d: a.md section = %q", jobs, body)
+		}
+		if body := bodies["b.md"]; !strings.Contains(body, "beta content") || strings.Contains(body, "alpha content") {
+			t.Errorf("jobs=%d: b.md section = %q", jobs, body)
+		}
+	}
+}
+
+// stable drops the lines that differ between otherwise identical runs.
+func stable(merged string) string {
+	var lines []string
+	for _, line := range strings.Split(merged, "\n") {
+		if !strings.HasPrefix(line, "Generated on: ") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRenderParallelMatchesSequential(t *testing.T) {
+	files := make(map[string]string)
+	for _, name := range []string{"c.md", "a.md", "e.md", "b.md", "d.md", "f.md", "g.md"} {
+		files[name] = "---\ntitle: " + name + "\n---\n# " + name + "\n\nSee [other](other.md).\n\n" + strings.Repeat(name+" line\n", 50)
+	}
+	dir := writeFiles(t, files)
+
+	sequential := New(dir, filepath.Join(t.TempDir(), "merged.md"))
+	sequential.Jobs = 1
+	want := stable(render(t, sequential))
+
+	tests := []struct {
+		name string
+		jobs int
+	}{
+		{"two jobs", 2},
+		{"more jobs than documents", 16},
+		{"zero jobs", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(dir, filepath.Join(t.TempDir(), "merged.md"))
+			m.Jobs = tt.jobs
+			if got := stable(render(t, m)); got != want {
+				t.Errorf("output with %d jobs differs from the sequential output", tt.jobs)
+			}
+		})
+	}
+
+	// Documents are written in filename order
+	last := -1
+	for _, name := range []string{"a.md", "b.md", "c.md", "d.md", "e.md", "f.md", "g.md"} {
+		i := strings.Index(want, "## Document: "+name)
+		if i < last {
+			t.Errorf("%s is out of order", name)
+		}
+		last = i
+	}
+}
+
+func TestRenderCache(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.md": "# A\n\nfirst version\n",
+		"b.md": "# B\n\nunchanged\n",
+	})
+	cacheDir := t.TempDir()
+	outputFile := filepath.Join(t.TempDir(), "merged.md")
+
+	run := func() (string, map[string]bool) {
+		cached := make(map[string]bool)
+		m := New(dir, outputFile)
+		m.CacheDir = cacheDir
+		m.Jobs = 2
+		m.OnEvent = func(e events.Event) {
+			if e.Kind == events.DocumentMerged {
+				cached[filepath.Base(e.Path)] = e.Cached
+			}
+		}
+		return render(t, m), cached
+	}
+
+	tests := []struct {
+		name       string
+		edit       string
+		wantCached map[string]bool
+		wantText   string
+	}{
+		{"first run fills the cache", "", map[string]bool{"a.md": false, "b.md": false}, "first version"},
+		{"second run hits the cache", "", map[string]bool{"a.md": true, "b.md": true}, "first version"},
+		{"edited file misses the cache", "# A\n\nsecond version\n", map[string]bool{"a.md": false, "b.md": true}, "second version"},
+	}
+	for _, tt := range tests {
+		if tt.edit != "" {
+			if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte(tt.edit), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		merged, cached := run()
+		for name, want := range tt.wantCached {
+			if cached[name] != want {
+				t.Errorf("%s: %s cached = %t, want %t", tt.name, name, cached[name], want)
+			}
+		}
+		if body := documentBodies(t, merged)["a.md"]; !strings.Contains(body, tt.wantText) {
+			t.Errorf("%s: a.md section = %q, want %q", tt.name, body, tt.wantText)
+		}
+	}
+}
+
+func TestProcessLinks(t *testing.T) {
+	m := New("docs", "merged.md")
+	tests := []struct {
+		in, want string
+	}{
+		{"See [guide](guide.md).", "See [guide](docs/guide.md)."},
+		{"[web](https://example.com) and [top](#top)", "[web](https://example.com) and [top](#top)"},
+		{"[abs](/etc/hosts) [a](a.md) [b](sub/b.md)", "[abs](/etc/hosts) [a](docs/a.md) [b](docs/sub/b.md)"},
+		{"no links", "no links"},
+	}
+	for _, tt := range tests {
+		if got := m.processLinks(tt.in); got != tt.want {
+			t.Errorf("processLinks(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEqualIgnoringVolatileLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"identical", "# Doc\ntext\n", "# Doc\ntext\n", true},
+		{"generation time", "Generated on: 2024-01-01 10:00:00\ntext\n", "Generated on: 2025-06-30 23:59:59\ntext\n", true},
+		{"modification time", "**Modified:** 2024-01-01 10:00:00\ntext\n", "**Modified:** 2024-02-02 11:11:11\ntext\n", true},
+		{"volatile line on one side only", "Generated on: now\ntext\n", "text\n", true},
+		{"changed line", "# Doc\nold\n", "# Doc\nnew\n", false},
+		{"extra line", "# Doc\n", "# Doc\nmore\n", false},
+		{"missing line", "# Doc\nmore\n", "# Doc\n", false},
+		{"size is not volatile", "**Size:** 10 bytes\n", "**Size:** 11 bytes\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EqualIgnoringVolatileLines(strings.NewReader(tt.a), strings.NewReader(tt.b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("EqualIgnoringVolatileLines = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
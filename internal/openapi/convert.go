@@ -0,0 +1,313 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+)
+
+// maxExampleDepth bounds the nesting of examples generated from schemas.
+const maxExampleDepth = 6
+
+// API converts the document into the documentation model. Examples given
+// in the document are used as they are; missing ones are generated from
+// the schemas.
+func (d *Document) API(source string) apispec.API {
+	api := apispec.API{
+		Title:       d.Info.Title,
+		Version:     d.Info.Version,
+		Description: d.Info.Description,
+		Source:      source,
+		Tags:        make(map[string]string),
+	}
+	if len(d.Servers) > 0 {
+		api.BaseURL = d.Servers[0].URL
+	}
+	for _, tag := range d.Tags {
+		api.Tags[tag.Name] = tag.Description
+	}
+
+	for _, path := range d.SortedPaths() {
+		item := d.Paths[path]
+		operations := item.Operations()
+		for _, method := range Methods {
+			op, ok := operations[method]
+			if !ok {
+				continue
+			}
+			api.Endpoints = append(api.Endpoints, convertOperation(method, path, item, op))
+		}
+	}
+
+	return api
+}
+
+func convertOperation(method, path string, item PathItem, op *Operation) apispec.Endpoint {
+	endpoint := apispec.Endpoint{
+		Method:      method,
+		Path:        path,
+		Summary:     firstNonEmpty(op.Summary, item.Summary),
+		Description: firstNonEmpty(op.Description, item.Description),
+		Deprecated:  op.Deprecated,
+	}
+	if len(op.Tags) > 0 {
+		endpoint.Tag = op.Tags[0]
+	}
+
+	for _, p := range mergeParameters(item.Parameters, op.Parameters) {
+		param := apispec.Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Type:        schemaType(p.Schema),
+			Required:    p.Required || p.In == "path",
+			Description: p.Description,
+		}
+		example := p.Example
+		if example == nil && p.Schema != nil {
+			example = firstNonNil(p.Schema.Example, p.Schema.Default, firstEnum(p.Schema))
+		}
+		if example != nil {
+			param.Example = fmt.Sprint(example)
+		}
+		endpoint.Parameters = append(endpoint.Parameters, param)
+	}
+
+	if op.RequestBody != nil {
+		contentType, media := preferredContent(op.RequestBody.Content)
+		endpoint.RequestBody = &apispec.Body{
+			ContentType: contentType,
+			Description: op.RequestBody.Description,
+			Required:    op.RequestBody.Required,
+			Example:     renderExample(media),
+		}
+	}
+
+	statuses := make([]string, 0, len(op.Responses))
+	for status := range op.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		// "default" sorts after the numbered statuses
+		if (statuses[i] == "default") != (statuses[j] == "default") {
+			return statuses[j] == "default"
+		}
+		return statuses[i] < statuses[j]
+	})
+	for _, status := range statuses {
+		response := op.Responses[status]
+		contentType, media := preferredContent(response.Content)
+		endpoint.Responses = append(endpoint.Responses, apispec.Response{
+			Status:      status,
+			Description: response.Description,
+			ContentType: contentType,
+			Example:     renderExample(media),
+		})
+	}
+
+	return endpoint
+}
+
+// mergeParameters applies operation parameters over path-level ones with
+// the same name and location.
+func mergeParameters(pathParams, opParams []Parameter) []Parameter {
+	var merged []Parameter
+	for _, p := range pathParams {
+		overridden := false
+		for _, o := range opParams {
+			if o.Name == p.Name && o.In == p.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, opParams...)
+}
+
+// preferredContent picks the JSON media type if there is one, otherwise
+// the first in lexical order.
+func preferredContent(content map[string]MediaType) (string, *MediaType) {
+	if len(content) == 0 {
+		return "", nil
+	}
+
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+
+	chosen := types[0]
+	for _, contentType := range types {
+		if strings.Contains(contentType, "json") {
+			chosen = contentType
+			break
+		}
+	}
+	media := content[chosen]
+	return chosen, &media
+}
+
+// renderExample returns the media type's example as indented JSON.
+func renderExample(media *MediaType) string {
+	if media == nil {
+		return ""
+	}
+
+	value := media.Example
+	if value == nil && len(media.Examples) > 0 {
+		names := make([]string, 0, len(media.Examples))
+		for name := range media.Examples {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		value = media.Examples[names[0]].Value
+	}
+	if value == nil && media.Schema != nil {
+		value = ExampleValue(media.Schema)
+	}
+	if value == nil {
+		return ""
+	}
+
+	if text, ok := value.(string); ok && (media.Schema == nil || media.Schema.Type != "string") {
+		return strings.TrimRight(text, "\n")
+	}
+	data, err := json.MarshalIndent(normalize(value), "", "  ")
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// ExampleValue builds an example value for a schema, preferring the
+// schema's own example, default and enum values.
+func ExampleValue(schema *Schema) any {
+	return exampleValue(schema, 0)
+}
+
+func exampleValue(schema *Schema, depth int) any {
+	if schema == nil || depth > maxExampleDepth {
+		return nil
+	}
+	if value := firstNonNil(schema.Example, schema.Default, firstEnum(schema)); value != nil {
+		return value
+	}
+	if schema.CircularRef != "" {
+		return map[string]any{}
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := make(map[string]any)
+		for _, part := range schema.AllOf {
+			if object, ok := exampleValue(part, depth+1).(map[string]any); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	if len(schema.OneOf) > 0 {
+		return exampleValue(schema.OneOf[0], depth+1)
+	}
+	if len(schema.AnyOf) > 0 {
+		return exampleValue(schema.AnyOf[0], depth+1)
+	}
+
+	switch baseType(schema) {
+	case "object":
+		object := make(map[string]any, len(schema.Properties))
+		for name, property := range schema.Properties {
+			object[name] = exampleValue(property, depth+1)
+		}
+		return object
+	case "array":
+		if item := exampleValue(schema.Items, depth+1); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case "integer":
+		return 0
+	case "number":
+		return 0.0
+	case "boolean":
+		return true
+	default:
+		return stringExample(schema.Format)
+	}
+}
+
+func stringExample(format string) string {
+	switch format {
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "date":
+		return "2024-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "uri", "url":
+		return "https://example.com"
+	default:
+		return "string"
+	}
+}
+
+// schemaType describes the schema's type for documentation, e.g.
+// "array of string".
+func schemaType(schema *Schema) string {
+	typ := baseType(schema)
+	if typ == "array" && schema.Items != nil {
+		return fmt.Sprintf("array of %s", schemaType(schema.Items))
+	}
+	return typ
+}
+
+// baseType returns the schema's type, inferring object and array from
+// properties and items when it is not given.
+func baseType(schema *Schema) string {
+	switch {
+	case schema == nil:
+		return "string"
+	case schema.Type != "":
+		return string(schema.Type)
+	case len(schema.Properties) > 0 || len(schema.AllOf) > 0 || schema.CircularRef != "":
+		return "object"
+	case schema.Items != nil:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+func firstEnum(schema *Schema) any {
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+	return nil
+}
+
+func firstNonNil(values ...any) any {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"gopkg.in/yaml.v3"
+)
+
+// exportVersion is the OpenAPI version of exported documents.
+const exportVersion = "3.0.3"
+
+// FromAPI builds an OpenAPI document from documented endpoints. Schemas
+// are inferred from the JSON examples.
+func FromAPI(api apispec.API) *Document {
+	doc := &Document{
+		OpenAPI: exportVersion,
+		Info: Info{
+			Title:       api.Title,
+			Description: api.Description,
+			Version:     api.Version,
+		},
+		Paths: make(map[string]PathItem),
+	}
+	if api.BaseURL != "" {
+		doc.Servers = []Server{{URL: api.BaseURL}}
+	}
+
+	seenTags := make(map[string]bool)
+	for _, endpoint := range api.Endpoints {
+		if endpoint.Tag != "" && !seenTags[endpoint.Tag] {
+			seenTags[endpoint.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: endpoint.Tag, Description: api.Tags[endpoint.Tag]})
+		}
+
+		item := doc.Paths[endpoint.Path]
+		op := exportOperation(endpoint)
+		switch endpoint.Method {
+		case "GET":
+			item.Get = op
+		case "POST":
+			item.Post = op
+		case "PUT":
+			item.Put = op
+		case "PATCH":
+			item.Patch = op
+		case "DELETE":
+			item.Delete = op
+		case "HEAD":
+			item.Head = op
+		case "OPTIONS":
+			item.Options = op
+		case "TRACE":
+			item.Trace = op
+		}
+		doc.Paths[endpoint.Path] = item
+	}
+
+	return doc
+}
+
+func exportOperation(endpoint apispec.Endpoint) *Operation {
+	op := &Operation{
+		Summary:     endpoint.Summary,
+		Description: endpoint.Description,
+		Deprecated:  endpoint.Deprecated,
+		Responses:   make(map[string]Response),
+	}
+	if endpoint.Tag != "" {
+		op.Tags = []string{endpoint.Tag}
+	}
+
+	for _, p := range endpoint.Parameters {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      typeSchema(p.Type),
+		})
+	}
+
+	if body := endpoint.RequestBody; body != nil {
+		op.RequestBody = &RequestBody{
+			Description: body.Description,
+			Required:    body.Required,
+			Content:     map[string]MediaType{body.ContentType: exampleMedia(body.Example)},
+		}
+	}
+
+	for _, r := range endpoint.Responses {
+		response := Response{Description: r.Description}
+		if response.Description == "" {
+			response.Description = defaultDescription(r.Status)
+		}
+		if r.Example != "" {
+			contentType := r.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			response.Content = map[string]MediaType{contentType: exampleMedia(r.Example)}
+		}
+		op.Responses[r.Status] = response
+	}
+	if len(op.Responses) == 0 {
+		op.Responses["200"] = Response{Description: "OK"}
+	}
+
+	return op
+}
+
+func defaultDescription(status string) string {
+	if code, err := strconv.Atoi(status); err == nil {
+		if text := http.StatusText(code); text != "" {
+			return text
+		}
+	}
+	return "Response"
+}
+
+// exampleMedia describes a body from its example, inferring the schema
+// when the example is JSON.
+func exampleMedia(example string) MediaType {
+	if strings.TrimSpace(example) == "" {
+		return MediaType{Schema: &Schema{Type: "object"}}
+	}
+
+	var value any
+	decoder := json.NewDecoder(strings.NewReader(example))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return MediaType{Schema: &Schema{Type: "string"}, Example: example}
+	}
+	value = plainNumbers(value)
+	return MediaType{Schema: InferSchema(value), Example: value}
+}
+
+// plainNumbers converts json.Number values to int64 or float64.
+func plainNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, child := range v {
+			v[key] = plainNumbers(child)
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = plainNumbers(child)
+		}
+	}
+	return value
+}
+
+// InferSchema returns a schema describing an example value.
+func InferSchema(value any) *Schema {
+	switch v := value.(type) {
+	case map[string]any:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema, len(v))}
+		for key, child := range v {
+			schema.Properties[key] = InferSchema(child)
+		}
+		return schema
+	case []any:
+		schema := &Schema{Type: "array", Items: &Schema{}}
+		if len(v) > 0 {
+			schema.Items = InferSchema(v[0])
+		}
+		return schema
+	case int64, int:
+		return &Schema{Type: "integer"}
+	case float64:
+		return &Schema{Type: "number"}
+	case bool:
+		return &Schema{Type: "boolean"}
+	case nil:
+		return &Schema{Nullable: true}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// typeSchema turns a documented type such as "integer" or "array of
+// string" into a schema.
+func typeSchema(typ string) *Schema {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	if item, ok := strings.CutPrefix(typ, "array of "); ok {
+		return &Schema{Type: "array", Items: typeSchema(item)}
+	}
+	if strings.HasSuffix(typ, "[]") {
+		return &Schema{Type: "array", Items: typeSchema(strings.TrimSuffix(typ, "[]"))}
+	}
+
+	switch typ {
+	case "integer", "int", "int32", "int64", "long":
+		return &Schema{Type: "integer"}
+	case "number", "float", "double", "decimal":
+		return &Schema{Type: "number"}
+	case "boolean", "bool":
+		return &Schema{Type: "boolean"}
+	case "object", "array":
+		return &Schema{Type: SchemaType(typ)}
+	case "uuid", "date", "date-time", "email", "uri":
+		return &Schema{Type: "string", Format: typ}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// Marshal encodes the document as YAML.
+func (d *Document) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(d); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
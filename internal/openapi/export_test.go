@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+const petstore = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: 2.4.1
+servers:
+  - url: https://api.example.com/v2
+paths:
+  /pets:
+    get:
+      summary: List pets
+      tags: [pets]
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: The pets
+          content:
+            application/json:
+              example: [{"id": 1, "name": "Rex"}]
+    post:
+      summary: Add a pet
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            example: {"name": "Rex"}
+      responses:
+        "201":
+          description: Created
+  /pets/{id}:
+    delete:
+      summary: Remove a pet
+      tags: [pets]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "204":
+          description: Removed
+`
+
+// operations lists the "METHOD path" pairs of doc.
+func operations(doc *Document) []string {
+	var ops []string
+	for _, path := range doc.SortedPaths() {
+		for method := range doc.Paths[path].Operations() {
+			ops = append(ops, method+" "+path)
+		}
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+func TestImportExportRoundTrip(t *testing.T) {
+	imported, err := Parse([]byte(petstore))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Import: write the API docs as import openapi does
+	dir := t.TempDir()
+	g := apispec.New(filepath.Join(dir, "specs", "api.md"), filepath.Join(dir, "specs", "api"))
+	g.Output = &output.Writer{Log: io.Discard}
+	if err := g.Generate(imported.API("petstore.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Export: read them back as export openapi does
+	files := apispec.DocFiles(dir)
+	endpoints, warnings, err := apispec.ParseFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, warning := range warnings {
+		t.Errorf("warning: %s", warning)
+	}
+	index, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := apispec.ParseInfo(string(index))
+	api.Title = "Petstore"
+	api.Endpoints = endpoints
+
+	data, err := FromAPI(api).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := Parse(data)
+	if err != nil {
+		t.Fatalf("exported document does not parse: %v\n%s", err, data)
+	}
+
+	if exported.Info.Version != "2.4.1" {
+		t.Errorf("info.version = %q, want 2.4.1", exported.Info.Version)
+	}
+	if len(exported.Servers) != 1 || exported.Servers[0].URL != "https://api.example.com/v2" {
+		t.Errorf("servers = %+v, want https://api.example.com/v2", exported.Servers)
+	}
+	if got, want := strings.Join(operations(exported), ", "), strings.Join(operations(imported), ", "); got != want {
+		t.Errorf("operations = %s, want %s", got, want)
+	}
+}
+
+func TestParseInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantVersion string
+		wantBaseURL string
+	}{
+		{
+			name:        "imported index",
+			content:     "# Shop API Documentation\n\nSells things.\n\n- **Version:** 1.2.0\n- **Base URL:** `https://shop.example.com`\n- **Source:** `shop.yaml`\n\n## Endpoints\n",
+			wantVersion: "1.2.0",
+			wantBaseURL: "https://shop.example.com",
+		},
+		{
+			name:    "labels inside endpoint sections are not API info",
+			content: "# API\n\n## GET /api/items\n\n- **Version:** 9\n",
+		},
+		{
+			name:    "template without info",
+			content: "# API Documentation\n\n## Endpoints\n\n### GET /api/endpoint\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := apispec.ParseInfo(tt.content)
+			if api.Version != tt.wantVersion || api.BaseURL != tt.wantBaseURL {
+				t.Errorf("ParseInfo = version %q, base URL %q; want %q, %q", api.Version, api.BaseURL, tt.wantVersion, tt.wantBaseURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+// Package openapi reads OpenAPI 3 documents and converts them to and from
+// the endpoint model used by the API documentation.
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is the subset of an OpenAPI 3 document used for documentation.
+type Document struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Info       Info                `yaml:"info"`
+	Servers    []Server            `yaml:"servers,omitempty"`
+	Tags       []Tag               `yaml:"tags,omitempty"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components *Components         `yaml:"components,omitempty"`
+}
+
+type Tag struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+}
+
+type Info struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description,omitempty"`
+	Version     string `yaml:"version"`
+}
+
+type Server struct {
+	URL         string `yaml:"url"`
+	Description string `yaml:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas,omitempty"`
+}
+
+type PathItem struct {
+	Summary     string      `yaml:"summary,omitempty"`
+	Description string      `yaml:"description,omitempty"`
+	Parameters  []Parameter `yaml:"parameters,omitempty"`
+	Get         *Operation  `yaml:"get,omitempty"`
+	Put         *Operation  `yaml:"put,omitempty"`
+	Post        *Operation  `yaml:"post,omitempty"`
+	Delete      *Operation  `yaml:"delete,omitempty"`
+	Options     *Operation  `yaml:"options,omitempty"`
+	Head        *Operation  `yaml:"head,omitempty"`
+	Patch       *Operation  `yaml:"patch,omitempty"`
+	Trace       *Operation  `yaml:"trace,omitempty"`
+}
+
+type Operation struct {
+	OperationID string              `yaml:"operationId,omitempty"`
+	Summary     string              `yaml:"summary,omitempty"`
+	Description string              `yaml:"description,omitempty"`
+	Tags        []string            `yaml:"tags,omitempty"`
+	Parameters  []Parameter         `yaml:"parameters,omitempty"`
+	RequestBody *RequestBody        `yaml:"requestBody,omitempty"`
+	Responses   map[string]Response `yaml:"responses"`
+	Deprecated  bool                `yaml:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Description string  `yaml:"description,omitempty"`
+	Required    bool    `yaml:"required,omitempty"`
+	Schema      *Schema `yaml:"schema,omitempty"`
+	Example     any     `yaml:"example,omitempty"`
+}
+
+type RequestBody struct {
+	Description string               `yaml:"description,omitempty"`
+	Required    bool                 `yaml:"required,omitempty"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+type Response struct {
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema   *Schema            `yaml:"schema,omitempty"`
+	Example  any                `yaml:"example,omitempty"`
+	Examples map[string]Example `yaml:"examples,omitempty"`
+}
+
+type Example struct {
+	Summary string `yaml:"summary,omitempty"`
+	Value   any    `yaml:"value,omitempty"`
+}
+
+type Schema struct {
+	Type        SchemaType         `yaml:"type,omitempty"`
+	Format      string             `yaml:"format,omitempty"`
+	Description string             `yaml:"description,omitempty"`
+	Properties  map[string]*Schema `yaml:"properties,omitempty"`
+	Required    []string           `yaml:"required,omitempty"`
+	Items       *Schema            `yaml:"items,omitempty"`
+	Enum        []any              `yaml:"enum,omitempty"`
+	Default     any                `yaml:"default,omitempty"`
+	Example     any                `yaml:"example,omitempty"`
+	AllOf       []*Schema          `yaml:"allOf,omitempty"`
+	OneOf       []*Schema          `yaml:"oneOf,omitempty"`
+	AnyOf       []*Schema          `yaml:"anyOf,omitempty"`
+	Nullable    bool               `yaml:"nullable,omitempty"`
+	// CircularRef names the schema a cyclic $ref pointed to; the cycle is
+	// not expanded.
+	CircularRef string `yaml:"x-circular-ref,omitempty"`
+}
+
+// SchemaType is a schema's type. OpenAPI 3.1 type lists are reduced to
+// their first type other than null.
+type SchemaType string
+
+func (t *SchemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		var types []string
+		if err := node.Decode(&types); err != nil {
+			return err
+		}
+		for _, typ := range types {
+			if typ != "null" {
+				*t = SchemaType(typ)
+				return nil
+			}
+		}
+		*t = "null"
+		return nil
+	}
+
+	var typ string
+	if err := node.Decode(&typ); err != nil {
+		return err
+	}
+	*t = SchemaType(typ)
+	return nil
+}
+
+// Load reads an OpenAPI 3 document in YAML or JSON from path and resolves
+// its local $ref references.
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes an OpenAPI 3 document in YAML or JSON and resolves its
+// local $ref references.
+func Parse(data []byte) (*Document, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	root, ok := normalize(raw).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: not a mapping")
+	}
+	if _, ok := root["swagger"]; ok {
+		return nil, fmt.Errorf("only OpenAPI 3 documents are supported, found Swagger %v", root["swagger"])
+	}
+	if version, _ := root["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("only OpenAPI 3 documents are supported, found openapi %q", version)
+	}
+
+	resolved, err := resolve(root, root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round-trip the resolved tree into the typed model
+	encoded, err := yaml.Marshal(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	var doc Document
+	if err := yaml.Unmarshal(encoded, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+	return &doc, nil
+}
+
+// normalize converts mappings with non-string keys, such as unquoted
+// response codes, into string-keyed maps.
+func normalize(node any) any {
+	switch value := node.(type) {
+	case map[string]any:
+		for key, child := range value {
+			value[key] = normalize(child)
+		}
+		return value
+	case map[any]any:
+		converted := make(map[string]any, len(value))
+		for key, child := range value {
+			converted[fmt.Sprint(key)] = normalize(child)
+		}
+		return converted
+	case []any:
+		for i, child := range value {
+			value[i] = normalize(child)
+		}
+		return value
+	default:
+		return node
+	}
+}
+
+// resolve returns node with every local $ref replaced by its target.
+// References that lead back into themselves are replaced by a schema
+// marked with x-circular-ref.
+func resolve(node, root any, stack []string) (any, error) {
+	switch value := node.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok {
+			for _, seen := range stack {
+				if seen == ref {
+					return map[string]any{"x-circular-ref": refName(ref)}, nil
+				}
+			}
+			target, err := lookup(root, ref)
+			if err != nil {
+				return nil, err
+			}
+			return resolve(target, root, append(stack, ref))
+		}
+
+		resolved := make(map[string]any, len(value))
+		for key, child := range value {
+			r, err := resolve(child, root, stack)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = r
+		}
+		return resolved, nil
+
+	case []any:
+		resolved := make([]any, len(value))
+		for i, child := range value {
+			r, err := resolve(child, root, stack)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+
+	default:
+		return node, nil
+	}
+}
+
+// lookup follows a local JSON pointer such as #/components/schemas/User.
+func lookup(root any, ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("unsupported $ref %s: only references within the document are supported", ref)
+	}
+
+	node := root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		mapping, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolved $ref %s", ref)
+		}
+		if node, ok = mapping[token]; !ok {
+			return nil, fmt.Errorf("unresolved $ref %s", ref)
+		}
+	}
+	return node, nil
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// Methods lists the HTTP methods in the order operations are documented.
+var Methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE"}
+
+// Operations returns the operations of item keyed by upper-case method.
+func (item PathItem) Operations() map[string]*Operation {
+	operations := map[string]*Operation{
+		"GET":     item.Get,
+		"POST":    item.Post,
+		"PUT":     item.Put,
+		"PATCH":   item.Patch,
+		"DELETE":  item.Delete,
+		"HEAD":    item.Head,
+		"OPTIONS": item.Options,
+		"TRACE":   item.Trace,
+	}
+	for method, op := range operations {
+		if op == nil {
+			delete(operations, method)
+		}
+	}
+	return operations
+}
+
+// SortedPaths returns the document's paths in lexical order.
+func (d *Document) SortedPaths() []string {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
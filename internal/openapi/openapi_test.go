@@ -0,0 +1,184 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{"yaml", "openapi: 3.0.3\ninfo: {title: T, version: '1'}\npaths: {}\n", ""},
+		{"json", `{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}, "paths": {}}`, ""},
+		{"swagger", "swagger: '2.0'\ninfo: {title: T, version: '1'}\n", "found Swagger 2.0"},
+		{"missing version", "info: {title: T}\n", `found openapi ""`},
+		{"not a mapping", "- a\n- b\n", "not a mapping"},
+		{"remote ref", "openapi: 3.0.0\npaths:\n  /a:\n    $ref: 'other.yaml#/paths/a'\n", "only references within the document"},
+		{"missing ref", "openapi: 3.0.0\npaths:\n  /a:\n    $ref: '#/components/pathItems/a'\n", "unresolved $ref #/components/pathItems/a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.doc))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseResolvesRefs(t *testing.T) {
+	doc, err := Parse([]byte(`openapi: 3.0.3
+info: {title: T, version: '1'}
+paths:
+  /users/{id}:
+    parameters:
+      - $ref: '#/components/parameters/ID'
+    get:
+      responses:
+        200:
+          description: A user
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  parameters:
+    ID: {name: id, in: path, schema: {type: string}}
+  schemas:
+    User:
+      type: object
+      properties:
+        name: {type: string}
+        manager:
+          $ref: '#/components/schemas/User'
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := doc.Paths["/users/{id}"]
+	if len(item.Parameters) != 1 || item.Parameters[0].Name != "id" {
+		t.Errorf("parameters = %+v, want the referenced id parameter", item.Parameters)
+	}
+	// Unquoted response codes are keyed as strings
+	schema := item.Get.Responses["200"].Content["application/json"].Schema
+	if schema == nil || schema.Properties["name"] == nil {
+		t.Fatalf("schema = %+v, want the referenced User schema", schema)
+	}
+	if got := schema.Properties["manager"].CircularRef; got != "User" {
+		t.Errorf("circular reference = %q, want User", got)
+	}
+}
+
+func TestAPI(t *testing.T) {
+	doc, err := Parse([]byte(`openapi: 3.1.0
+info: {title: Shop, version: '3', description: Orders}
+servers: [{url: 'https://shop.example.com'}]
+tags: [{name: orders, description: Order handling}]
+paths:
+  /orders/{id}:
+    summary: An order
+    parameters:
+      - {name: id, in: path, schema: {type: string, format: uuid}}
+      - {name: expand, in: query, schema: {type: string}}
+    delete:
+      tags: [orders]
+      deprecated: true
+      responses:
+        default: {description: Error}
+        '204': {description: Deleted}
+    put:
+      tags: [orders]
+      parameters:
+        - {name: expand, in: query, required: true, schema: {type: [string, 'null'], enum: [items]}}
+      requestBody:
+        required: true
+        content:
+          text/plain: {schema: {type: string}}
+          application/json:
+            schema:
+              type: object
+              properties:
+                at: {type: string, format: date-time}
+                lines: {type: array, items: {type: integer}}
+      responses:
+        '200':
+          description: Updated
+          content:
+            application/json:
+              examples:
+                second: {value: {id: 2}}
+                first: {value: {id: 1}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := doc.API("shop.yaml")
+
+	if api.Title != "Shop" || api.Version != "3" || api.BaseURL != "https://shop.example.com" || api.Tags["orders"] != "Order handling" {
+		t.Errorf("API info = %+v", api)
+	}
+	if len(api.Endpoints) != 2 || api.Endpoints[0].Method != "PUT" || api.Endpoints[1].Method != "DELETE" {
+		t.Fatalf("endpoints = %+v, want PUT then DELETE", api.Endpoints)
+	}
+
+	put := api.Endpoints[0]
+	if put.Summary != "An order" || put.Tag != "orders" {
+		t.Errorf("PUT summary and tag = %q %q", put.Summary, put.Tag)
+	}
+	var params []string
+	for _, p := range put.Parameters {
+		params = append(params, p.Name+" "+p.In+" "+p.Type+" "+p.Example)
+		if !p.Required {
+			t.Errorf("parameter %s is not required", p.Name)
+		}
+	}
+	if got := strings.Join(params, ", "); got != "id path string , expand query string items" {
+		t.Errorf("parameters = %s", got)
+	}
+	if put.RequestBody == nil || put.RequestBody.ContentType != "application/json" ||
+		put.RequestBody.Example != "{\n  \"at\": \"2024-01-01T00:00:00Z\",\n  \"lines\": [\n    0\n  ]\n}" {
+		t.Errorf("request body = %+v", put.RequestBody)
+	}
+	if len(put.Responses) != 1 || put.Responses[0].Example != "{\n  \"id\": 1\n}" {
+		t.Errorf("responses = %+v, want the first named example", put.Responses)
+	}
+
+	remove := api.Endpoints[1]
+	if !remove.Deprecated || len(remove.Responses) != 2 || remove.Responses[0].Status != "204" || remove.Responses[1].Status != "default" {
+		t.Errorf("DELETE = %+v", remove)
+	}
+}
+
+func TestExampleValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *Schema
+		want   string
+	}{
+		{"example wins", &Schema{Type: "integer", Example: 7, Default: 3}, "7"},
+		{"enum", &Schema{Type: "string", Enum: []any{"red", "blue"}}, "red"},
+		{"email", &Schema{Type: "string", Format: "email"}, "user@example.com"},
+		{"array", &Schema{Items: &Schema{Type: "boolean"}}, "[true]"},
+		{"allOf", &Schema{AllOf: []*Schema{
+			{Properties: map[string]*Schema{"a": {Type: "integer"}}},
+			{Properties: map[string]*Schema{"b": {Type: "string"}}},
+		}}, "map[a:0 b:string]"},
+		{"oneOf", &Schema{OneOf: []*Schema{{Type: "number"}, {Type: "string"}}}, "0"},
+		{"circular", &Schema{CircularRef: "Node"}, "map[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(ExampleValue(tt.schema)); got != tt.want {
+				t.Errorf("ExampleValue = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Package output writes generated files atomically and can preview the
+// planned changes instead of touching the filesystem.
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrExists is returned when a file would be overwritten without Force.
+var ErrExists = errors.New("file already exists")
+
+type Action string
+
+const (
+	Create    Action = "create"
+	Overwrite Action = "overwrite"
+	Unchanged Action = "unchanged"
+	Refuse    Action = "refuse"
+)
+
+type Writer struct {
+	// DryRun prints the planned changes to Log instead of writing them.
+	DryRun bool
+	// Force allows existing files to be overwritten.
+	Force bool
+	Log   io.Writer
+}
+
+func New() *Writer {
+	return &Writer{
+		Log: os.Stdout,
+	}
+}
+
+// Plan reports what writing data to path would do.
+func (w *Writer) Plan(path string, data []byte) (Action, error) {
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Create, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if bytes.Equal(existing, data) {
+		return Unchanged, nil
+	}
+	if !w.Force {
+		return Refuse, nil
+	}
+	return Overwrite, nil
+}
+
+// WriteFile writes data to path through a temporary file that is renamed
+// into place, so that a crash never leaves a half-written file behind.
+// Existing files are only replaced when Force is set. In dry-run mode the
+// planned change is printed, with a diff for overwrites, and nothing is written.
+func (w *Writer) WriteFile(path string, data []byte, perm os.FileMode) (Action, error) {
+	action, err := w.Plan(path, data)
+	if err != nil {
+		return "", err
+	}
+
+	if w.DryRun {
+		w.report(path, action, data)
+		return action, nil
+	}
+
+	switch action {
+	case Unchanged:
+		return action, nil
+	case Refuse:
+		return action, fmt.Errorf("%w: %s (use --force to overwrite)", ErrExists, path)
+	}
+
+	if err := writeAtomic(path, data, perm); err != nil {
+		return "", err
+	}
+	return action, nil
+}
+
+// MkdirAll creates dir and any missing parents. In dry-run mode it only
+// reports directories that would be created.
+func (w *Writer) MkdirAll(dir string) error {
+	if w.DryRun {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			fmt.Fprintf(w.Log, "create: %s/\n", filepath.ToSlash(dir))
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	return nil
+}
+
+// Remove deletes path. In dry-run mode it only reports the removal.
+func (w *Writer) Remove(path string) error {
+	if w.DryRun {
+		fmt.Fprintf(w.Log, "remove: %s\n", filepath.ToSlash(path))
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+func (w *Writer) report(path string, action Action, data []byte) {
+	name := filepath.ToSlash(path)
+
+	switch action {
+	case Create:
+		fmt.Fprintf(w.Log, "create: %s (%d bytes)\n", name, len(data))
+	case Unchanged:
+		fmt.Fprintf(w.Log, "unchanged: %s\n", name)
+	case Refuse:
+		fmt.Fprintf(w.Log, "refuse: %s already exists (use --force to overwrite)\n", name)
+	case Overwrite:
+		existing, _ := os.ReadFile(path)
+		fmt.Fprintf(w.Log, "overwrite: %s\n", name)
+		io.WriteString(w.Log, UnifiedDiff(name, string(existing), string(data)))
+	}
+}
+
+func writeAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fail := func(err error) error {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fail(err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// File is a file being streamed through a Writer. The destination is only
+// replaced when Commit is called.
+type File struct {
+	w    *Writer
+	path string
+	perm os.FileMode
+	tmp  *os.File
+
+	// In dry-run mode the content is compared with the existing file as
+	// it is written instead of being held in memory.
+	size     int64
+	existing *bufio.Reader
+	closer   io.Closer
+	differs  bool
+}
+
+// Create starts streaming a new version of path. Existing files are only
+// replaced when Force is set. In dry-run mode nothing is written: the
+// content is counted and compared with the existing file so that Commit
+// can report the planned change.
+func (w *Writer) Create(path string, perm os.FileMode) (*File, error) {
+	f := &File{w: w, path: path, perm: perm}
+	if w.DryRun {
+		existing, err := os.Open(path)
+		if err == nil {
+			f.existing = bufio.NewReader(existing)
+			f.closer = existing
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return f, nil
+	}
+
+	if _, err := os.Stat(path); err == nil && !w.Force {
+		return nil, fmt.Errorf("%w: %s (use --force to overwrite)", ErrExists, path)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	f.tmp = tmp
+	return f, nil
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	if f.w.DryRun {
+		f.size += int64(len(p))
+		if f.existing != nil && !f.differs {
+			f.differs = !f.matchExisting(p)
+		}
+		return len(p), nil
+	}
+	return f.tmp.Write(p)
+}
+
+// matchExisting reports whether the existing file continues with p.
+func (f *File) matchExisting(p []byte) bool {
+	buf := make([]byte, 32*1024)
+	for len(p) > 0 {
+		n := min(len(p), len(buf))
+		if _, err := io.ReadFull(f.existing, buf[:n]); err != nil || !bytes.Equal(buf[:n], p[:n]) {
+			return false
+		}
+		p = p[n:]
+	}
+	return true
+}
+
+// Commit moves the written content into place.
+func (f *File) Commit() error {
+	if f.w.DryRun {
+		f.reportStreamed()
+		return nil
+	}
+
+	tmp := f.tmp
+	if tmp == nil {
+		return fmt.Errorf("failed to write %s: file already committed or aborted", f.path)
+	}
+	f.tmp = nil
+
+	fail := func(err error) error {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", f.path, err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Chmod(f.perm); err != nil {
+		return fail(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fail(err)
+	}
+
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// reportStreamed reports the planned change of a dry run. Streamed
+// content is not kept, so overwrites are reported without a diff.
+func (f *File) reportStreamed() {
+	name := filepath.ToSlash(f.path)
+	if f.existing == nil {
+		fmt.Fprintf(f.w.Log, "create: %s (%d bytes)\n", name, f.size)
+		return
+	}
+	defer f.closer.Close()
+
+	if !f.differs {
+		if _, err := f.existing.ReadByte(); err == io.EOF {
+			fmt.Fprintf(f.w.Log, "unchanged: %s\n", name)
+			return
+		}
+	}
+	if !f.w.Force {
+		fmt.Fprintf(f.w.Log, "refuse: %s already exists (use --force to overwrite)\n", name)
+		return
+	}
+	fmt.Fprintf(f.w.Log, "overwrite: %s (%d bytes)\n", name, f.size)
+}
+
+// Abort discards the written content, leaving the destination untouched.
+func (f *File) Abort() {
+	if f.closer != nil {
+		f.closer.Close()
+		f.closer = nil
+	}
+	if f.tmp != nil {
+		f.tmp.Close()
+		os.Remove(f.tmp.Name())
+		f.tmp = nil
+	}
+}
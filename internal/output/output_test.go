@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileDryRunStreams(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string // empty for no file
+		force    bool
+		chunks   []string
+		want     string
+	}{
+		{"new file", "", false, []string{"hello ", "world\n"}, "create: out.md (12 bytes)"},
+		{"same content", "hello world\n", false, []string{"hello ", "world\n"}, "unchanged: out.md"},
+		{"longer existing file", "hello world\nmore\n", true, []string{"hello world\n"}, "overwrite: out.md (12 bytes)"},
+		{"longer new content", "hello", true, []string{"hello", " world\n"}, "overwrite: out.md (12 bytes)"},
+		{"changed without force", "hello there\n", false, []string{"hello world\n"}, "refuse: out.md already exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "out.md")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var log bytes.Buffer
+			w := &Writer{DryRun: true, Force: tt.force, Log: &log}
+			f, err := w.Create(path, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, chunk := range tt.chunks {
+				if _, err := f.Write([]byte(chunk)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := f.Commit(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := strings.ReplaceAll(log.String(), filepath.ToSlash(dir)+"/", "")
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("report = %q, want prefix %q", got, tt.want)
+			}
+			if data, _ := os.ReadFile(path); string(data) != tt.existing {
+				t.Errorf("dry run changed the file to %q", data)
+			}
+		})
+	}
+}
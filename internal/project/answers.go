@@ -0,0 +1,109 @@
+// Package project describes the project being documented: the answers
+// the init wizard collects, the defaults it detects from the files in the
+// project, and how the answers fill the generated context files.
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers describe a project for the init templates. Empty answers leave
+// the template placeholders in place.
+type Answers struct {
+	Name        string   `yaml:"name,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Stack       []string `yaml:"stack,omitempty"`
+	// Commands maps a purpose such as build or test to its command.
+	Commands map[string]string `yaml:"commands,omitempty"`
+	// Directories maps key directories to what they contain.
+	Directories map[string]string `yaml:"directories,omitempty"`
+	Constraints []string          `yaml:"constraints,omitempty"`
+}
+
+// commandOrder lists the usual command purposes in the order they are
+// asked for and documented.
+var commandOrder = []string{"install", "build", "test", "lint", "run"}
+
+// CommandNames returns the purposes of the commands, the usual ones first.
+func (a Answers) CommandNames() []string {
+	return orderedKeys(a.Commands, commandOrder)
+}
+
+// DirectoryNames returns the key directories in order.
+func (a Answers) DirectoryNames() []string {
+	return orderedKeys(a.Directories, nil)
+}
+
+func orderedKeys(m map[string]string, first []string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range first {
+		if _, ok := m[key]; ok {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	var rest []string
+	for key := range m {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// Load reads answers from a YAML file.
+func Load(path string) (Answers, error) {
+	var answers Answers
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return answers, fmt.Errorf("failed to read answers file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return answers, fmt.Errorf("failed to parse answers file %s: %w", path, err)
+	}
+	return answers, nil
+}
+
+// Marshal encodes the answers as YAML, in the format Load reads.
+func (a Answers) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(a); err != nil {
+		return nil, fmt.Errorf("failed to encode answers: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode answers: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Merge returns a with the non-empty answers of b taking precedence.
+func (a Answers) Merge(b Answers) Answers {
+	if b.Name != "" {
+		a.Name = b.Name
+	}
+	if b.Description != "" {
+		a.Description = b.Description
+	}
+	if len(b.Stack) > 0 {
+		a.Stack = b.Stack
+	}
+	if len(b.Commands) > 0 {
+		a.Commands = b.Commands
+	}
+	if len(b.Directories) > 0 {
+		a.Directories = b.Directories
+	}
+	if len(b.Constraints) > 0 {
+		a.Constraints = b.Constraints
+	}
+	return a
+}
@@ -0,0 +1,84 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnswersRoundTrip(t *testing.T) {
+	answers := Answers{
+		Name:        "shop",
+		Description: "An online shop",
+		Stack:       []string{"Go 1.22", "PostgreSQL"},
+		Commands:    map[string]string{"test": "make test", "build": "make", "deploy": "make deploy"},
+		Directories: map[string]string{"cmd/": "Entry points"},
+		Constraints: []string{"No CGO"},
+	}
+	data, err := answers.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, answers) {
+		t.Errorf("loaded %+v, want %+v", loaded, answers)
+	}
+
+	if got := strings.Join(loaded.CommandNames(), ","); got != "build,test,deploy" {
+		t.Errorf("CommandNames = %s, want the usual purposes first", got)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("stack: [unclosed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{filepath.Join(dir, "missing.yaml"), "failed to read answers file"},
+		{invalid, "failed to parse answers file"},
+	}
+	for _, tt := range tests {
+		if _, err := Load(tt.path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Load(%s) error = %v, want %q", filepath.Base(tt.path), err, tt.wantErr)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	detected := Answers{
+		Name:     "shop",
+		Stack:    []string{"Go"},
+		Commands: map[string]string{"test": "go test ./..."},
+	}
+	file := Answers{
+		Description: "An online shop",
+		Commands:    map[string]string{"test": "make test"},
+	}
+
+	merged := detected.Merge(file)
+	want := Answers{
+		Name:        "shop",
+		Description: "An online shop",
+		Stack:       []string{"Go"},
+		Commands:    map[string]string{"test": "make test"},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("Merge = %+v, want %+v", merged, want)
+	}
+}
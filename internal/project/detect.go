@@ -0,0 +1,210 @@
+package project
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// knownDirectories describes the directories projects commonly have.
+var knownDirectories = map[string]string{
+	"api":        "API definitions",
+	"app":        "Application code",
+	"cmd":        "Command entry points",
+	"components": "UI components",
+	"config":     "Configuration",
+	"docs":       "Documentation",
+	"internal":   "Private packages",
+	"lib":        "Libraries",
+	"migrations": "Database migrations",
+	"pkg":        "Public packages",
+	"public":     "Static assets",
+	"scripts":    "Development scripts",
+	"specs":      "Specifications",
+	"src":        "Source code",
+	"test":       "Tests",
+	"tests":      "Tests",
+	"web":        "Web frontend",
+}
+
+var (
+	goVersion    = regexp.MustCompile(`(?m)^go\s+(\S+)`)
+	goModule     = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	tomlString   = regexp.MustCompile(`(?m)^(name|description)\s*=\s*"([^"]*)"`)
+	makeTarget   = regexp.MustCompile(`(?m)^([A-Za-z][\w-]*)\s*:`)
+	markdownMark = regexp.MustCompile(`[*_` + "`" + `]|!?\[[^\]]*\]\([^)]*\)`)
+)
+
+// Detect returns the answers that can be inferred from the files in dir:
+// the stack and commands from go.mod, package.json, pyproject.toml,
+// requirements.txt, Cargo.toml and Makefile, the description from those
+// or the README, and the common directories present.
+func Detect(dir string) Answers {
+	answers := Answers{Commands: make(map[string]string), Directories: make(map[string]string)}
+	read := func(name string) (string, bool) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		return string(data), err == nil
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	if mod, ok := read("go.mod"); ok {
+		stack := "Go"
+		if m := goVersion.FindStringSubmatch(mod); m != nil {
+			stack += " " + m[1]
+		}
+		answers.Stack = append(answers.Stack, stack)
+		if m := goModule.FindStringSubmatch(mod); m != nil {
+			answers.Name = filepath.Base(m[1])
+		}
+		answers.Commands["build"] = "go build ./..."
+		answers.Commands["test"] = "go test ./..."
+		answers.Commands["lint"] = "go vet ./..."
+	}
+
+	if data, ok := read("package.json"); ok {
+		detectNode(data, &answers, exists)
+	}
+
+	if data, ok := read("pyproject.toml"); ok {
+		answers.Stack = append(answers.Stack, "Python")
+		for _, m := range tomlString.FindAllStringSubmatch(data, -1) {
+			if m[1] == "name" && answers.Name == "" {
+				answers.Name = m[2]
+			} else if m[1] == "description" && answers.Description == "" {
+				answers.Description = m[2]
+			}
+		}
+		answers.Commands["install"] = "pip install -e ."
+		answers.Commands["test"] = "pytest"
+	} else if exists("requirements.txt") {
+		answers.Stack = append(answers.Stack, "Python")
+		answers.Commands["install"] = "pip install -r requirements.txt"
+		answers.Commands["test"] = "pytest"
+	}
+
+	if data, ok := read("Cargo.toml"); ok {
+		answers.Stack = append(answers.Stack, "Rust")
+		if m := tomlString.FindStringSubmatch(data); m != nil && m[1] == "name" && answers.Name == "" {
+			answers.Name = m[2]
+		}
+		answers.Commands["build"] = "cargo build"
+		answers.Commands["test"] = "cargo test"
+		answers.Commands["lint"] = "cargo clippy"
+	}
+
+	// Make targets wrap the toolchain commands, so they are preferred
+	if data, ok := read("Makefile"); ok {
+		for _, m := range makeTarget.FindAllStringSubmatch(data, -1) {
+			for _, purpose := range commandOrder {
+				if m[1] == purpose {
+					answers.Commands[purpose] = "make " + purpose
+				}
+			}
+		}
+	}
+
+	if exists("Dockerfile") {
+		answers.Stack = append(answers.Stack, "Docker")
+	}
+
+	if answers.Description == "" {
+		if readme, ok := read("README.md"); ok {
+			answers.Description = readmeSummary(readme)
+		}
+	}
+
+	for name, purpose := range knownDirectories {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.IsDir() {
+			answers.Directories[name+"/"] = purpose
+		}
+	}
+
+	return answers
+}
+
+func detectNode(data string, answers *Answers, exists func(string) bool) {
+	var pkg struct {
+		Name            string            `json:"name"`
+		Description     string            `json:"description"`
+		Scripts         map[string]string `json:"scripts"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if json.Unmarshal([]byte(data), &pkg) != nil {
+		return
+	}
+
+	if answers.Name == "" {
+		answers.Name = pkg.Name
+	}
+	if answers.Description == "" {
+		answers.Description = pkg.Description
+	}
+
+	answers.Stack = append(answers.Stack, "Node.js")
+	frameworks := []struct{ dependency, name string }{
+		{"typescript", "TypeScript"},
+		{"next", "Next.js"},
+		{"react", "React"},
+		{"vue", "Vue"},
+		{"svelte", "Svelte"},
+		{"express", "Express"},
+		{"fastify", "Fastify"},
+	}
+	for _, framework := range frameworks {
+		_, dep := pkg.Dependencies[framework.dependency]
+		_, dev := pkg.DevDependencies[framework.dependency]
+		if dep || dev {
+			answers.Stack = append(answers.Stack, framework.name)
+		}
+	}
+
+	manager := "npm"
+	switch {
+	case exists("pnpm-lock.yaml"):
+		manager = "pnpm"
+	case exists("yarn.lock"):
+		manager = "yarn"
+	}
+	answers.Commands["install"] = manager + " install"
+	for _, purpose := range []string{"build", "test", "lint"} {
+		if _, ok := pkg.Scripts[purpose]; ok {
+			answers.Commands[purpose] = manager + " run " + purpose
+		}
+	}
+	for _, script := range []string{"dev", "start"} {
+		if _, ok := pkg.Scripts[script]; ok {
+			answers.Commands["run"] = manager + " run " + script
+			break
+		}
+	}
+}
+
+// readmeSummary returns the first paragraph of a README that is not a
+// heading, badge or HTML.
+func readmeSummary(readme string) string {
+	for _, paragraph := range strings.Split(strings.ReplaceAll(readme, "\r\n", "\n"), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(paragraph), "\n")
+		for len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
+			lines = lines[1:]
+		}
+		paragraph = strings.TrimSpace(strings.Join(lines, "\n"))
+		if paragraph == "" || strings.ContainsAny(paragraph[:1], "#<|>-*`") ||
+			strings.HasPrefix(paragraph, "[![") || strings.HasPrefix(paragraph, "![") {
+			continue
+		}
+		text := markdownMark.ReplaceAllStringFunc(paragraph, func(s string) string {
+			if strings.HasPrefix(s, "[") {
+				return s[1:strings.Index(s, "]")]
+			}
+			return ""
+		})
+		return strings.Join(strings.Fields(text), " ")
+	}
+	return ""
+}
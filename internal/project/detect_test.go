@@ -0,0 +1,79 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  Answers
+	}{
+		{
+			name: "go with make",
+			files: map[string]string{
+				"go.mod":      "module github.com/acme/shop\n\ngo 1.22\n",
+				"Makefile":    "build:\n\tgo build\ntest: build\n\tgo test\n",
+				"README.md":   "# Shop\n\n[![CI](badge.svg)](ci)\n\nAn **online** shop.\n",
+				"cmd/main.go": "package main\n",
+			},
+			want: Answers{
+				Name:        "shop",
+				Description: "An online shop.",
+				Stack:       []string{"Go 1.22"},
+				Commands:    map[string]string{"build": "make build", "test": "make test", "lint": "go vet ./..."},
+				Directories: map[string]string{"cmd/": "Command entry points"},
+			},
+		},
+		{
+			name: "node with yarn",
+			files: map[string]string{
+				"package.json": `{"name": "web", "description": "Storefront", "scripts": {"dev": "next", "test": "jest"}, "dependencies": {"next": "14", "react": "18"}}`,
+				"yarn.lock":    "",
+				"Dockerfile":   "FROM node\n",
+			},
+			want: Answers{
+				Name:        "web",
+				Description: "Storefront",
+				Stack:       []string{"Node.js", "Next.js", "React", "Docker"},
+				Commands:    map[string]string{"install": "yarn install", "test": "yarn run test", "run": "yarn run dev"},
+				Directories: map[string]string{},
+			},
+		},
+		{
+			name: "python project",
+			files: map[string]string{
+				"pyproject.toml": "[project]\nname = \"tool\"\ndescription = \"A tool\"\n",
+			},
+			want: Answers{
+				Name:        "tool",
+				Description: "A tool",
+				Stack:       []string{"Python"},
+				Commands:    map[string]string{"install": "pip install -e .", "test": "pytest"},
+				Directories: map[string]string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := Detect(dir); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Detect =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
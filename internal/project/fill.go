@@ -0,0 +1,133 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+// Fill replaces the placeholder sections of a generated context file with
+// the answers. CLAUDE.md gets the overview, stack, commands and key
+// directories, .claude/context.md the overview and constraints; other
+// files are returned unchanged.
+func Fill(path, content string, a Answers) string {
+	name := a.Name
+	overview := ""
+	if a.Description != "" {
+		overview = a.Description
+		if name != "" && !strings.HasPrefix(a.Description, name) {
+			overview = name + " - " + a.Description
+		}
+	}
+
+	switch path {
+	case "CLAUDE.md":
+		if overview != "" {
+			content = setSection(content, 2, "Project Overview", overview, "")
+		}
+		if len(a.Stack) > 0 {
+			var b strings.Builder
+			b.WriteString("**Core Technologies:**\n")
+			for _, item := range a.Stack {
+				fmt.Fprintf(&b, "- %s\n", item)
+			}
+			content = setSection(content, 2, "Architecture & Technology Stack", b.String(), "")
+		}
+		if len(a.Commands) > 0 {
+			var b strings.Builder
+			b.WriteString("```bash\n")
+			names := a.CommandNames()
+			width := 0
+			for _, purpose := range names {
+				width = max(width, len(a.Commands[purpose]))
+			}
+			for _, purpose := range names {
+				fmt.Fprintf(&b, "%-*s  # %s\n", width, a.Commands[purpose], capitalize(purpose))
+			}
+			b.WriteString("```")
+			content = setSection(content, 2, "Common Commands", b.String(), "Key Files & Components")
+		}
+		if len(a.Directories) > 0 {
+			content = setSection(content, 2, "Key Files & Components", directoryList(a), "")
+		}
+
+	case ".claude/context.md":
+		if overview != "" {
+			content = setSection(content, 2, "Project Overview", overview, "")
+		}
+		if len(a.Constraints) > 0 {
+			var b strings.Builder
+			for _, constraint := range a.Constraints {
+				fmt.Fprintf(&b, "- %s\n", constraint)
+			}
+			content = setSection(content, 3, "Technical Constraints", b.String(), "")
+		}
+	}
+
+	return content
+}
+
+func directoryList(a Answers) string {
+	var b strings.Builder
+	for _, dir := range a.DirectoryNames() {
+		if purpose := a.Directories[dir]; purpose != "" {
+			fmt.Fprintf(&b, "- `%s` - %s\n", dir, purpose)
+		} else {
+			fmt.Fprintf(&b, "- `%s`\n", dir)
+		}
+	}
+	return b.String()
+}
+
+// setSection replaces the body of the section with the given heading. A
+// missing section is inserted before the before section, or appended
+// when that is missing too.
+func setSection(content string, level int, title, body, before string) string {
+	body = strings.TrimRight(body, "\n") + "\n"
+	blocks := markdown.Blocks(content)
+
+	find := func(title string) int {
+		for i, block := range blocks {
+			if block.Kind == markdown.Heading && block.Level == level && block.Title == title {
+				return i
+			}
+		}
+		return -1
+	}
+
+	if i := find(title); i >= 0 {
+		end := len(content)
+		for _, block := range blocks[i+1:] {
+			if block.Kind == markdown.Heading && block.Level <= level {
+				end = block.Start
+				break
+			}
+		}
+		if end < len(content) {
+			body += "\n"
+		}
+		// Keep the template's spacing after the heading
+		if strings.HasPrefix(content[blocks[i].End:], "\n") {
+			body = "\n" + body
+		}
+		return content[:blocks[i].End] + body + content[end:]
+	}
+
+	section := strings.Repeat("#", level) + " " + title + "\n\n" + body
+	if j := find(before); j >= 0 && before != "" {
+		return content[:blocks[j].Start] + section + "\n" + content[blocks[j].Start:]
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + section
+}
+
+// capitalize returns s with its first letter in upper case.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,95 @@
+package project
+
+import "testing"
+
+const claudeTemplate = `# CLAUDE.md
+
+## Project Overview
+
+[Describe the project]
+
+## Architecture & Technology Stack
+
+[List technologies]
+
+## Key Files & Components
+
+[List key files]
+`
+
+func TestFill(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		answers Answers
+		want    string
+	}{
+		{
+			name:    "CLAUDE.md",
+			path:    "CLAUDE.md",
+			content: claudeTemplate,
+			answers: Answers{
+				Name:        "shop",
+				Description: "An online shop",
+				Stack:       []string{"Go"},
+				Commands:    map[string]string{"test": "go test ./...", "build": "make"},
+				Directories: map[string]string{"cmd/": "Entry points", "web/": ""},
+			},
+			want: `# CLAUDE.md
+
+## Project Overview
+
+shop - An online shop
+
+## Architecture & Technology Stack
+
+**Core Technologies:**
+- Go
+
+## Common Commands
+
+` + "```bash\nmake           # Build\ngo test ./...  # Test\n```" + `
+
+## Key Files & Components
+
+- ` + "`cmd/`" + ` - Entry points
+- ` + "`web/`" + `
+`,
+		},
+		{
+			name:    "empty and non-ASCII command purposes",
+			path:    "CLAUDE.md",
+			content: "## Common Commands\n\n[Commands]\n",
+			answers: Answers{Commands: map[string]string{"": "make all", "übersetzen": "msgfmt"}},
+			want:    "## Common Commands\n\n```bash\nmake all  # \nmsgfmt    # Übersetzen\n```\n",
+		},
+		{
+			name:    "empty answers keep placeholders",
+			path:    "CLAUDE.md",
+			content: claudeTemplate,
+			want:    claudeTemplate,
+		},
+		{
+			name:    "context constraints are appended",
+			path:    ".claude/context.md",
+			content: "# Context\n\n## Project Overview\n\n[Overview]\n",
+			answers: Answers{Description: "shop for things", Name: "shop", Constraints: []string{"No CGO", "Go 1.21"}},
+			want:    "# Context\n\n## Project Overview\n\nshop for things\n\n### Technical Constraints\n\n- No CGO\n- Go 1.21\n",
+		},
+		{
+			name:    "other files are unchanged",
+			path:    ".claude/debug-log.md",
+			content: "## Project Overview\n",
+			answers: Answers{Description: "An online shop"},
+			want:    "## Project Overview\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fill(tt.path, tt.content, tt.answers); got != tt.want {
+				t.Errorf("Fill =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
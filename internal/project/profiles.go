@@ -0,0 +1,192 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the project configuration file read from the project root.
+const ConfigFile = ".claude-docs.yaml"
+
+// DefaultProfile is the profile init uses when none is configured. It
+// creates the same files init created before profiles existed.
+const DefaultProfile = "full"
+
+// Profile is a selection of the files and directories init creates.
+type Profile struct {
+	// Extends names a profile whose files and directories this one adds to.
+	Extends string   `yaml:"extends,omitempty"`
+	Files   []string `yaml:"files,omitempty"`
+	Dirs    []string `yaml:"dirs,omitempty"`
+	// Skip removes files of the extended profile.
+	Skip []string `yaml:"skip,omitempty"`
+}
+
+// Config is the project configuration in ConfigFile.
+type Config struct {
+	DefaultProfile string             `yaml:"default_profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// Profiles are the built-in profiles, following the single file, domain
+// split and hierarchical approaches of the specification guidelines.
+var Profiles = map[string]Profile{
+	"minimal": {
+		Files: []string{"CLAUDE.md"},
+	},
+	"standard": {
+		Extends: "minimal",
+		Files: []string{
+			".claude/context.md",
+			".claude/project-knowledge.md",
+			".claude/common-patterns.md",
+			".claude/debug-log.md",
+			"specs/api.md",
+			"specs/screens.md",
+		},
+	},
+	"full": {
+		Extends: "standard",
+		Files: []string{
+			".claude/project-improvements.md",
+			".claude/project-specifics.md",
+			".claude/ai-collaboration.md",
+			".claude/code-patterns.md",
+		},
+	},
+	"hierarchical": {
+		Extends: "full",
+		Dirs:    []string{"specs/api", "specs/screens", "specs/features"},
+	},
+}
+
+// LoadConfig reads ConfigFile from dir. A missing file is an empty
+// configuration.
+func LoadConfig(dir string) (Config, error) {
+	var config Config
+	path := filepath.Join(dir, ConfigFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ProfileNames returns the built-in and configured profile names.
+func (c Config) ProfileNames() []string {
+	var names []string
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	for name := range c.Profiles {
+		if _, builtin := Profiles[name]; !builtin {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the files and directories of a profile, following
+// Extends. An empty name selects the configured default profile, or
+// DefaultProfile. Configured profiles take precedence over built-in ones
+// of the same name.
+func (c Config) Resolve(name string) (files, dirs []string, err error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	return c.resolve(name, make(map[string]bool))
+}
+
+func (c Config) resolve(name string, visiting map[string]bool) ([]string, []string, error) {
+	profile, ok := c.Profiles[name]
+	if !ok {
+		profile, ok = Profiles[name]
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+	}
+	if visiting[name] {
+		return nil, nil, fmt.Errorf("profile %q extends itself", name)
+	}
+	visiting[name] = true
+
+	var files, dirs []string
+	if profile.Extends != "" {
+		var err error
+		if files, dirs, err = c.resolveBase(profile.Extends, name, visiting); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	files = appendNew(files, profile.Files...)
+	dirs = appendNew(dirs, profile.Dirs...)
+	files = remove(files, profile.Skip)
+	return files, dirs, nil
+}
+
+// resolveBase resolves the profile extended by name. A configured
+// profile may extend the built-in profile it replaces.
+func (c Config) resolveBase(base, name string, visiting map[string]bool) ([]string, []string, error) {
+	if _, builtin := Profiles[base]; builtin && base == name {
+		return Config{}.resolve(base, make(map[string]bool))
+	}
+	return c.resolve(base, visiting)
+}
+
+func appendNew(list []string, items ...string) []string {
+	for _, item := range items {
+		item = filepath.ToSlash(filepath.Clean(item))
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func remove(list, items []string) []string {
+	var kept []string
+	for _, entry := range list {
+		skip := false
+		for _, item := range items {
+			if MatchFile(entry, item) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// MatchFile reports whether a file selector names path: the path itself,
+// its file name or its file name without .md, so that "context" selects
+// ".claude/context.md".
+func MatchFile(path, selector string) bool {
+	selector = filepath.ToSlash(filepath.Clean(selector))
+	base := filepath.Base(path)
+	return path == selector || base == selector || strings.TrimSuffix(base, ".md") == selector
+}
@@ -0,0 +1,104 @@
+package project
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	// The files init created before profiles existed
+	full := []string{
+		"CLAUDE.md",
+		".claude/ai-collaboration.md",
+		".claude/code-patterns.md",
+		".claude/common-patterns.md",
+		".claude/context.md",
+		".claude/debug-log.md",
+		".claude/project-improvements.md",
+		".claude/project-knowledge.md",
+		".claude/project-specifics.md",
+		"specs/api.md",
+		"specs/screens.md",
+	}
+
+	tests := []struct {
+		name      string
+		config    Config
+		profile   string
+		wantFiles []string
+		wantDirs  []string
+		wantErr   string
+	}{
+		{
+			name:      "default profile keeps the original files",
+			wantFiles: full,
+		},
+		{
+			name:      "hierarchical adds spec directories",
+			profile:   "hierarchical",
+			wantFiles: full,
+			wantDirs:  []string{"specs/api", "specs/features", "specs/screens"},
+		},
+		{
+			name:      "minimal",
+			profile:   "minimal",
+			wantFiles: []string{"CLAUDE.md"},
+		},
+		{
+			name: "configured default extends and skips",
+			config: Config{DefaultProfile: "backend", Profiles: map[string]Profile{
+				"backend": {Extends: "standard", Files: []string{"./specs/api.md"}, Dirs: []string{"specs/api/"}, Skip: []string{"screens", "debug-log"}},
+			}},
+			wantFiles: []string{"CLAUDE.md", ".claude/common-patterns.md", ".claude/context.md", ".claude/project-knowledge.md", "specs/api.md"},
+			wantDirs:  []string{"specs/api"},
+		},
+		{
+			name: "configured profile replaces a built-in one",
+			config: Config{Profiles: map[string]Profile{
+				"minimal": {Extends: "minimal", Files: []string{".claude/context.md"}},
+			}},
+			profile:   "minimal",
+			wantFiles: []string{"CLAUDE.md", ".claude/context.md"},
+		},
+		{
+			name:    "unknown profile",
+			profile: "huge",
+			wantErr: `unknown profile "huge" (available: full, hierarchical, minimal, standard)`,
+		},
+		{
+			name: "cycle",
+			config: Config{Profiles: map[string]Profile{
+				"a": {Extends: "b"},
+				"b": {Extends: "a"},
+			}},
+			profile: "a",
+			wantErr: `profile "a" extends itself`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, dirs, err := tt.config.Resolve(tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sort.Strings(files)
+			sort.Strings(dirs)
+			want := append([]string(nil), tt.wantFiles...)
+			sort.Strings(want)
+			if strings.Join(files, ",") != strings.Join(want, ",") {
+				t.Errorf("files = %v, want %v", files, want)
+			}
+			if strings.Join(dirs, ",") != strings.Join(tt.wantDirs, ",") {
+				t.Errorf("dirs = %v, want %v", dirs, tt.wantDirs)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Prompter asks for answers on a terminal, offering defaults that are
+// accepted by pressing Enter. A single "-" clears a default.
+type Prompter struct {
+	In  *bufio.Reader
+	Out io.Writer
+}
+
+// NewPrompter returns a prompter reading from in and writing to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{In: bufio.NewReader(in), Out: out}
+}
+
+// Ask collects the answers, starting from defaults such as those Detect
+// returns.
+func (p *Prompter) Ask(defaults Answers) (Answers, error) {
+	answers := Answers{Commands: make(map[string]string), Directories: make(map[string]string)}
+	var err error
+
+	if answers.Name, err = p.line("Project name", defaults.Name); err != nil {
+		return answers, err
+	}
+	if answers.Description, err = p.line("Project description", defaults.Description); err != nil {
+		return answers, err
+	}
+
+	stack, err := p.line("Technology stack (comma-separated)", strings.Join(defaults.Stack, ", "))
+	if err != nil {
+		return answers, err
+	}
+	answers.Stack = splitList(stack)
+
+	fmt.Fprintln(p.Out, "Commands (leave empty to skip):")
+	for _, purpose := range defaults.CommandNames() {
+		if err := p.command(&answers, purpose, defaults.Commands[purpose]); err != nil {
+			return answers, err
+		}
+	}
+	for _, purpose := range commandOrder {
+		if _, asked := defaults.Commands[purpose]; !asked {
+			if err := p.command(&answers, purpose, ""); err != nil {
+				return answers, err
+			}
+		}
+	}
+
+	dirs, err := p.line("Key directories (comma-separated)", strings.Join(defaults.DirectoryNames(), ", "))
+	if err != nil {
+		return answers, err
+	}
+	for _, dir := range splitList(dirs) {
+		if !strings.HasSuffix(dir, "/") {
+			dir += "/"
+		}
+		purpose, err := p.line(fmt.Sprintf("  What is in %s", dir), defaults.Directories[dir])
+		if err != nil {
+			return answers, err
+		}
+		answers.Directories[dir] = purpose
+	}
+
+	fmt.Fprintln(p.Out, "Constraints, one per line (empty line to finish):")
+	for _, def := range defaults.Constraints {
+		constraint, err := p.line("  -", def)
+		if err != nil {
+			return answers, err
+		}
+		if constraint != "" {
+			answers.Constraints = append(answers.Constraints, constraint)
+		}
+	}
+	for {
+		constraint, err := p.line("  -", "")
+		if err != nil {
+			return answers, err
+		}
+		if constraint == "" {
+			break
+		}
+		answers.Constraints = append(answers.Constraints, constraint)
+	}
+
+	return answers, nil
+}
+
+func (p *Prompter) command(answers *Answers, purpose, def string) error {
+	command, err := p.line("  "+capitalize(purpose), def)
+	if err == nil && command != "" {
+		answers.Commands[purpose] = command
+	}
+	return err
+}
+
+// line asks one question and returns the answer, or def for an empty one.
+func (p *Prompter) line(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.Out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.Out, "%s: ", question)
+	}
+
+	text, err := p.In.ReadString('\n')
+	if err != nil && (err != io.EOF || text == "") {
+		if err == io.EOF {
+			fmt.Fprintln(p.Out)
+			return "", fmt.Errorf("input ended before the wizard finished")
+		}
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+
+	text = strings.TrimSpace(text)
+	switch text {
+	case "":
+		return def, nil
+	case "-":
+		return "", nil
+	}
+	return text, nil
+}
+
+func splitList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
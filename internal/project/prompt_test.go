@@ -0,0 +1,89 @@
+package project
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAsk(t *testing.T) {
+	defaults := Answers{
+		Name:        "shop",
+		Description: "An online shop",
+		Stack:       []string{"Go"},
+		Commands:    map[string]string{"test": "go test ./...", "lint": "go vet ./..."},
+		Directories: map[string]string{"cmd/": "Entry points"},
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Answers
+		wantErr string
+	}{
+		{
+			name: "accept defaults",
+			// name, description, stack, test, lint, install, build, run,
+			// directories, cmd/ purpose, end of constraints
+			input: strings.Repeat("\n", 11),
+			want: Answers{
+				Name:        "shop",
+				Description: "An online shop",
+				Stack:       []string{"Go"},
+				Commands:    map[string]string{"test": "go test ./...", "lint": "go vet ./..."},
+				Directories: map[string]string{"cmd/": "Entry points"},
+			},
+		},
+		{
+			name: "override and clear",
+			input: "\n-\nGo, Redis\nmake test\n-\n\nmake\n\n" +
+				"cmd, web\n\nFrontend\nNo CGO\n\n",
+			want: Answers{
+				Name:        "shop",
+				Stack:       []string{"Go", "Redis"},
+				Commands:    map[string]string{"test": "make test", "build": "make"},
+				Directories: map[string]string{"cmd/": "Entry points", "web/": "Frontend"},
+				Constraints: []string{"No CGO"},
+			},
+		},
+		{
+			name:    "input ends early",
+			input:   "shop\n",
+			wantErr: "input ended before the wizard finished",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPrompter(strings.NewReader(tt.input), io.Discard).Ask(defaults)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("answers = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAskConstraints(t *testing.T) {
+	defaults := Answers{Constraints: []string{"No CGO", "Go 1.21", "Postgres only"}}
+	// Skip to the constraints, keep the first, remove the second, replace
+	// the third and add one
+	input := strings.Repeat("\n", 9) + "\n-\nGo 1.22\nNo network in tests\n\n"
+
+	got, err := NewPrompter(strings.NewReader(input), io.Discard).Ask(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"No CGO", "Go 1.22", "No network in tests"}
+	if !reflect.DeepEqual(got.Constraints, want) {
+		t.Errorf("constraints = %q, want %q", got.Constraints, want)
+	}
+}
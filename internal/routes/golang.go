@@ -0,0 +1,266 @@
+package routes
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+)
+
+var httpMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "PATCH": true,
+	"DELETE": true, "HEAD": true, "OPTIONS": true,
+}
+
+// clientReceiver matches the names of HTTP clients, whose Get and Post
+// methods send requests rather than register routes.
+var clientReceiver = regexp.MustCompile(`(?i)^(http|\w*client)$`)
+
+// goScanner collects route registrations from one Go file. Route prefixes
+// are tracked per variable name: gin and echo groups, gorilla/mux
+// subrouters and chi Route blocks.
+type goScanner struct {
+	fset *token.FileSet
+	file string
+	refs []apispec.Reference
+	// consumed holds registrations already recorded by an enclosing
+	// gorilla/mux Methods call.
+	consumed map[*ast.CallExpr]bool
+}
+
+func scanGo(file string) ([]apispec.Reference, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &goScanner{fset: fset, file: file, consumed: make(map[*ast.CallExpr]bool)}
+	s.walk(f, make(map[string]string))
+	return s.refs, nil
+}
+
+func (s *goScanner) walk(node ast.Node, prefixes map[string]string) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range n.Rhs {
+				if i < len(n.Lhs) {
+					s.assign(n.Lhs[i], rhs, prefixes)
+				}
+			}
+		case *ast.ValueSpec:
+			for i, value := range n.Values {
+				if i < len(n.Names) {
+					s.assign(n.Names[i], value, prefixes)
+				}
+			}
+		case *ast.CallExpr:
+			return s.call(n, prefixes)
+		}
+		return true
+	})
+}
+
+// assign records the prefix of a group or subrouter assigned to a variable.
+func (s *goScanner) assign(lhs, rhs ast.Expr, prefixes map[string]string) {
+	name := exprName(lhs)
+	call, ok := rhs.(*ast.CallExpr)
+	if name == "" || !ok {
+		return
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	// gorilla/mux: r.PathPrefix("/api").Subrouter()
+	if sel.Sel.Name == "Subrouter" {
+		if inner, ok := sel.X.(*ast.CallExpr); ok {
+			call = inner
+			if sel, ok = inner.Fun.(*ast.SelectorExpr); !ok {
+				return
+			}
+		}
+	}
+
+	switch sel.Sel.Name {
+	case "Group", "PathPrefix":
+		if len(call.Args) > 0 {
+			if prefix, ok := stringLit(call.Args[0]); ok {
+				prefixes[name] = joinPath(prefixes[exprName(sel.X)], prefix)
+			}
+		}
+	}
+}
+
+func (s *goScanner) call(call *ast.CallExpr, prefixes map[string]string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || s.consumed[call] {
+		return true
+	}
+	name := sel.Sel.Name
+	prefix := prefixes[exprName(sel.X)]
+
+	switch name {
+	case "Route":
+		// chi: r.Route("/users", func(r chi.Router) { ... })
+		if len(call.Args) == 2 {
+			path, ok := stringLit(call.Args[0])
+			fn, isFunc := call.Args[1].(*ast.FuncLit)
+			if ok && isFunc {
+				inner := make(map[string]string, len(prefixes)+1)
+				for k, v := range prefixes {
+					inner[k] = v
+				}
+				for _, field := range fn.Type.Params.List {
+					for _, param := range field.Names {
+						inner[param.Name] = joinPath(prefix, path)
+					}
+				}
+				s.walk(fn.Body, inner)
+				return false
+			}
+		}
+
+	case "Methods":
+		// gorilla/mux: r.HandleFunc("/users", h).Methods("GET", "POST")
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		innerSel, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok || (innerSel.Sel.Name != "HandleFunc" && innerSel.Sel.Name != "Handle") || len(inner.Args) == 0 {
+			return true
+		}
+		path, ok := stringLit(inner.Args[0])
+		if !ok {
+			return true
+		}
+		innerPrefix := prefixes[exprName(innerSel.X)]
+		for _, arg := range call.Args {
+			if method, ok := methodArg(arg); ok {
+				s.add(method, joinPath(innerPrefix, path), inner)
+			}
+		}
+		s.consumed[inner] = true
+
+	case "HandleFunc", "Handle", "Method", "MethodFunc":
+		// chi Method("GET", "/x", h) and gin Handle("GET", "/x", h)
+		if len(call.Args) >= 2 {
+			if method, ok := methodArg(call.Args[0]); ok {
+				if path, ok := stringLit(call.Args[1]); ok {
+					s.add(method, joinPath(prefix, path), call)
+					return true
+				}
+			}
+		}
+		// net/http: mux.HandleFunc("GET /users/{id}", h) or ("/users", h)
+		if len(call.Args) >= 1 {
+			if pattern, ok := stringLit(call.Args[0]); ok {
+				method, path := splitPattern(pattern)
+				if strings.HasPrefix(path, "/") {
+					s.add(method, joinPath(prefix, path), call)
+				}
+			}
+		}
+
+	case "Get", "Post", "Put", "Patch", "Delete", "Head", "Options",
+		"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "Any":
+		// chi r.Get("/x", h), gin r.GET("/x", h), echo e.GET("/x", h), but
+		// not requests such as http.Post(url, "application/json", body)
+		if len(call.Args) >= 2 && handlerArg(call.Args[1]) && !clientReceiver.MatchString(exprName(sel.X)) {
+			if path, ok := stringLit(call.Args[0]); ok && (strings.HasPrefix(path, "/") || (path == "" && prefix != "")) {
+				method := strings.ToUpper(name)
+				if name == "Any" {
+					method = apispec.AnyMethod
+				}
+				s.add(method, joinPath(prefix, path), call)
+			}
+		}
+	}
+
+	return true
+}
+
+func (s *goScanner) add(method, path string, node ast.Node) {
+	s.refs = append(s.refs, apispec.Reference{
+		Method: method,
+		Path:   path,
+		File:   s.file,
+		Line:   s.fset.Position(node.Pos()).Line,
+	})
+}
+
+// handlerArg reports whether expr can be a handler: a function literal or
+// a reference to one, or a call building one such as a middleware chain.
+func handlerArg(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.FuncLit, *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr:
+		return true
+	}
+	return false
+}
+
+// splitPattern splits a Go 1.22 ServeMux pattern such as
+// "GET example.com/users/{id}" into its method and path.
+func splitPattern(pattern string) (string, string) {
+	method := apispec.AnyMethod
+	if i := strings.IndexByte(pattern, ' '); i >= 0 && httpMethods[pattern[:i]] {
+		method = pattern[:i]
+		pattern = strings.TrimSpace(pattern[i+1:])
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:] // drop the host
+	}
+	return method, pattern
+}
+
+// methodArg returns the method named by a string literal or an
+// http.MethodX constant.
+func methodArg(expr ast.Expr) (string, bool) {
+	if value, ok := stringLit(expr); ok {
+		value = strings.ToUpper(value)
+		return value, httpMethods[value]
+	}
+	if sel, ok := expr.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "Method") {
+		value := strings.ToUpper(strings.TrimPrefix(sel.Sel.Name, "Method"))
+		return value, httpMethods[value]
+	}
+	return "", false
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+// exprName names the variable or field an expression refers to.
+func exprName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	default:
+		return ""
+	}
+}
+
+func joinPath(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	if path == "" || path == "/" {
+		return prefix
+	}
+	return strings.TrimRight(prefix, "/") + "/" + strings.TrimLeft(path, "/")
+}
@@ -0,0 +1,128 @@
+// Package routes finds the HTTP routes registered in a codebase: Go
+// routes through the syntax tree, for net/http, gorilla/mux, chi, gin and
+// echo, and Express routes in JavaScript and TypeScript by pattern.
+package routes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+)
+
+// skippedDirs are never searched for routes.
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+	"dist":         true,
+	"build":        true,
+}
+
+// Scan returns the routes registered in the Go, JavaScript and TypeScript
+// files under root, sorted by path and method. Test files and hidden,
+// vendored and build directories are skipped.
+func Scan(root string) ([]apispec.Reference, error) {
+	var refs []apispec.Reference
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case strings.HasSuffix(name, "_test.go"):
+			return nil
+		case strings.HasSuffix(name, ".go"):
+			found, err := scanGo(path)
+			if err != nil {
+				// Files that do not parse cannot register routes we could find
+				return nil
+			}
+			refs = append(refs, found...)
+		case isScript(name):
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			refs = append(refs, scanExpress(path, string(content))...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dedupe(refs), nil
+}
+
+func isScript(name string) bool {
+	if strings.HasSuffix(name, ".d.ts") || strings.Contains(name, ".test.") || strings.Contains(name, ".spec.") {
+		return false
+	}
+	switch filepath.Ext(name) {
+	case ".js", ".mjs", ".cjs", ".ts":
+		return true
+	}
+	return false
+}
+
+var (
+	// expressRoutePattern matches app.get('/path', ...) and similar calls
+	// on receivers named like an app or router, so that HTTP clients such
+	// as axios.get('/path') are not mistaken for routes.
+	expressRoutePattern = regexp.MustCompile("\\b([A-Za-z_$][\\w$]*)\\s*\\.\\s*(get|post|put|patch|delete|head|options|all)\\s*\\(\\s*['\"`](/[^'\"`]*)['\"`]")
+	expressReceiver     = regexp.MustCompile(`(?i)^(app|server|\w*router|\w*routes?|\w*app)$`)
+)
+
+func scanExpress(file, content string) []apispec.Reference {
+	var refs []apispec.Reference
+	for _, match := range expressRoutePattern.FindAllStringSubmatchIndex(content, -1) {
+		receiver := content[match[2]:match[3]]
+		if !expressReceiver.MatchString(receiver) {
+			continue
+		}
+		method := strings.ToUpper(content[match[4]:match[5]])
+		if method == "ALL" {
+			method = apispec.AnyMethod
+		}
+		refs = append(refs, apispec.Reference{
+			Method: method,
+			Path:   content[match[6]:match[7]],
+			File:   file,
+			Line:   strings.Count(content[:match[0]], "\n") + 1,
+		})
+	}
+	return refs
+}
+
+// dedupe drops repeated registrations of the same route and sorts the rest.
+func dedupe(refs []apispec.Reference) []apispec.Reference {
+	seen := make(map[string]bool)
+	var unique []apispec.Reference
+	for _, ref := range refs {
+		if !seen[ref.Key()] {
+			seen[ref.Key()] = true
+			unique = append(unique, ref)
+		}
+	}
+
+	sort.SliceStable(unique, func(i, j int) bool {
+		if unique[i].Path != unique[j].Path {
+			return unique[i].Path < unique[j].Path
+		}
+		return unique[i].Method < unique[j].Method
+	})
+	return unique
+}
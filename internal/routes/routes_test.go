@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanGo(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "chi",
+			body: `r := chi.NewRouter()
+	r.Get("/users", listUsers)
+	r.Route("/orders", func(r chi.Router) {
+		r.Post("/", createOrder)
+		r.Delete("/{id}", deleteOrder)
+	})`,
+			want: []string{"GET /users", "POST /orders", "DELETE /orders/{id}"},
+		},
+		{
+			name: "gin groups and middleware",
+			body: `api := router.Group("/api")
+	api.GET("/items", auth(), handlers.ListItems)
+	api.Any("/proxy", proxy)`,
+			want: []string{"GET /api/items", "* /api/proxy"},
+		},
+		{
+			name: "net/http patterns",
+			body: `mux.HandleFunc("GET /health", health)
+	http.HandleFunc("/legacy", legacy)`,
+			want: []string{"GET /health", "* /legacy"},
+		},
+		{
+			name: "gorilla methods",
+			body: `s := r.PathPrefix("/v1").Subrouter()
+	s.HandleFunc("/things", things).Methods(http.MethodGet, "POST")`,
+			want: []string{"GET /v1/things", "POST /v1/things"},
+		},
+		{
+			name: "http client requests are not routes",
+			body: `http.Post("/api/login", "application/json", body)
+	http.Get("/api/health")
+	client.Post("/api/users", "application/json", nil)
+	apiClient.Get("/api/users", nil)
+	resty.New().R().Post("/api/orders")`,
+		},
+		{
+			name: "a string second argument is not a handler",
+			body: `c.Post("/api/upload", "text/plain", reader)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "routes.go")
+			source := "package main\n\nfunc setup() {\n\t" + tt.body + "\n}\n"
+			if err := os.WriteFile(file, []byte(source), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			refs, err := scanGo(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, ref := range refs {
+				got = append(got, ref.Method+" "+ref.Path)
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("routes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanExpress(t *testing.T) {
+	content := `app.get('/users', list)
+router.post("/users/:id", update)
+axios.get('/users')
+fetchClient.post('/users', body)
+app.all('/health', ok)
+`
+	var got []string
+	for _, ref := range scanExpress("server.js", content) {
+		got = append(got, ref.Method+" "+ref.Path)
+	}
+	if want := "GET /users, POST /users/:id, * /health"; strings.Join(got, ", ") != want {
+		t.Errorf("routes = %v, want %s", got, want)
+	}
+}
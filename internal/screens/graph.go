@@ -0,0 +1,221 @@
+package screens
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// IssueKind classifies a navigation problem.
+type IssueKind string
+
+const (
+	// Unreachable screens cannot be reached from an entry screen.
+	Unreachable IssueKind = "unreachable"
+	// Dangling navigation names a screen that is not specified.
+	Dangling IssueKind = "dangling"
+	// Asymmetric navigation is declared by only one of its two screens.
+	Asymmetric IssueKind = "asymmetric"
+)
+
+// Issue is a problem found in the navigation graph.
+type Issue struct {
+	Kind    IssueKind
+	File    string
+	Line    int
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.File == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s:%d: %s", filepath.ToSlash(i.File), i.Line, i.Message)
+}
+
+// Edge is navigation from one screen to another, declared as an exit of
+// From, an entry of To, or both.
+type Edge struct {
+	From, To string
+	Via      string
+	// Exit and Entry record which side declares the edge.
+	Exit, Entry bool
+	// Missing is set when To is not a specified screen.
+	Missing bool
+}
+
+// Graph is the navigation between the specified screens.
+type Graph struct {
+	Screens []Screen
+	// Roots are the keys of the screens users enter the app through.
+	Roots  map[string]bool
+	Edges  []Edge
+	Issues []Issue
+}
+
+// externalEntry matches entry points that lie outside the app's screens,
+// such as "App launch" or "Deep link", and make a screen an entry screen.
+var externalEntry = regexp.MustCompile(`(?i)\b(launch|start(up)?|deep ?links?|external|notifications?|url|anywhere|any screen|browser|e-?mail|outside|entry)\b`)
+
+// Build ties the screens' entry and exit points together and checks
+// them. Roots names entry screens in addition to those entered from
+// outside the app; without any, a single screen that nothing navigates
+// to is taken as the entry screen.
+func Build(screens []Screen, roots []string) *Graph {
+	g := &Graph{Roots: make(map[string]bool)}
+	seen := make(map[string]bool)
+	for _, screen := range screens {
+		if !seen[Key(screen.Name)] {
+			seen[Key(screen.Name)] = true
+			g.Screens = append(g.Screens, screen)
+		}
+	}
+	byKey := make(map[string]*Screen, len(g.Screens))
+	for i := range g.Screens {
+		byKey[Key(g.Screens[i].Name)] = &g.Screens[i]
+	}
+
+	edges := make(map[[2]string]int)
+	addEdge := func(from, to, via string) *Edge {
+		id := [2]string{from, to}
+		if i, ok := edges[id]; ok {
+			if g.Edges[i].Via == "" {
+				g.Edges[i].Via = via
+			}
+			return &g.Edges[i]
+		}
+		edges[id] = len(g.Edges)
+		g.Edges = append(g.Edges, Edge{From: from, To: to, Via: via})
+		return &g.Edges[len(g.Edges)-1]
+	}
+	issue := func(kind IssueKind, screen Screen, line int, format string, args ...any) {
+		g.Issues = append(g.Issues, Issue{Kind: kind, File: screen.File, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, screen := range g.Screens {
+		key := Key(screen.Name)
+		for _, exit := range screen.Exits {
+			target := Key(exit.Target)
+			if _, ok := byKey[target]; !ok {
+				issue(Dangling, screen, exit.Line, "%s: exit to unknown screen %q", screen.Name, exit.Target)
+				edge := addEdge(key, exit.Target, exit.Via)
+				edge.Exit, edge.Missing = true, true
+				continue
+			}
+			addEdge(key, target, exit.Via).Exit = true
+		}
+		for _, entry := range screen.Entries {
+			source := Key(entry.Target)
+			if _, ok := byKey[source]; !ok {
+				if externalEntry.MatchString(entry.Target) {
+					g.Roots[key] = true
+				} else {
+					issue(Dangling, screen, entry.Line, "%s: entry from unknown screen %q", screen.Name, entry.Target)
+				}
+				continue
+			}
+			addEdge(source, key, entry.Via).Entry = true
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if edge.Missing {
+			continue
+		}
+		from, to := byKey[edge.From], byKey[edge.To]
+		switch {
+		case edge.Exit && !edge.Entry:
+			issue(Asymmetric, *from, lineOf(from.Exits, edge.To, from.Line),
+				"%s lists %s as an exit point, but %s does not list %s as an entry point", from.Name, to.Name, to.Name, from.Name)
+		case edge.Entry && !edge.Exit:
+			issue(Asymmetric, *to, lineOf(to.Entries, edge.From, to.Line),
+				"%s lists %s as an entry point, but %s does not list %s as an exit point", to.Name, from.Name, from.Name, to.Name)
+		}
+	}
+
+	for _, root := range roots {
+		if _, ok := byKey[Key(root)]; ok {
+			g.Roots[Key(root)] = true
+		} else {
+			g.Issues = append(g.Issues, Issue{Kind: Dangling, Message: fmt.Sprintf("entry screen %q is not specified", root)})
+		}
+	}
+	g.checkReachable()
+
+	sort.SliceStable(g.Issues, func(i, j int) bool {
+		if g.Issues[i].File != g.Issues[j].File {
+			return g.Issues[i].File < g.Issues[j].File
+		}
+		return g.Issues[i].Line < g.Issues[j].Line
+	})
+	return g
+}
+
+// checkReachable reports the screens that cannot be reached from an
+// entry screen.
+func (g *Graph) checkReachable() {
+	incoming := make(map[string]bool)
+	next := make(map[string][]string)
+	for _, edge := range g.Edges {
+		if !edge.Missing && edge.From != edge.To {
+			incoming[edge.To] = true
+			next[edge.From] = append(next[edge.From], edge.To)
+		}
+	}
+
+	if len(g.Roots) == 0 {
+		var unentered []string
+		for _, screen := range g.Screens {
+			if !incoming[Key(screen.Name)] {
+				unentered = append(unentered, Key(screen.Name))
+			}
+		}
+		if len(unentered) == 1 {
+			g.Roots[unentered[0]] = true
+		}
+	}
+
+	reached := make(map[string]bool)
+	var queue []string
+	for root := range g.Roots {
+		reached[root] = true
+		queue = append(queue, root)
+	}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for _, to := range next[key] {
+			if !reached[to] {
+				reached[to] = true
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	for _, screen := range g.Screens {
+		if reached[Key(screen.Name)] {
+			continue
+		}
+		reason := "no entry screen leads to it"
+		if !incoming[Key(screen.Name)] {
+			reason = "no screen navigates to it"
+		}
+		g.Issues = append(g.Issues, Issue{
+			Kind:    Unreachable,
+			File:    screen.File,
+			Line:    screen.Line,
+			Message: fmt.Sprintf("%s is unreachable: %s", screen.Name, reason),
+		})
+	}
+}
+
+// lineOf returns the line declaring the link to target, or fallback.
+func lineOf(links []Link, target string, fallback int) int {
+	for _, link := range links {
+		if Key(link.Target) == target {
+			return link.Line
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,132 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	home := Screen{Name: "Home", File: "home.md", Line: 1,
+		Entries: []Link{{Target: "Login", Line: 3}},
+		Exits:   []Link{{Target: "Settings", Line: 6}},
+	}
+	login := Screen{Name: "Login", File: "login.md", Line: 1,
+		Entries: []Link{{Target: "App launch", Line: 3}},
+		Exits:   []Link{{Target: "Home screen", Line: 6}},
+	}
+	settings := Screen{Name: "Settings", File: "settings.md", Line: 1,
+		Entries: []Link{{Target: "Home", Line: 3}},
+	}
+
+	tests := []struct {
+		name       string
+		screens    []Screen
+		roots      []string
+		wantRoots  string
+		wantIssues []string
+	}{
+		{
+			name:      "consistent",
+			screens:   []Screen{home, login, settings},
+			wantRoots: "login",
+		},
+		{
+			name: "dangling and asymmetric",
+			screens: []Screen{
+				{Name: "Home", File: "home.md", Line: 1, Exits: []Link{{Target: "Profile", Line: 4}, {Target: "Settings", Line: 5}}},
+				{Name: "Settings", File: "settings.md", Line: 1, Entries: []Link{{Target: "Search", Line: 3}}},
+			},
+			wantRoots: "home",
+			wantIssues: []string{
+				`dangling home.md:4: Home: exit to unknown screen "Profile"`,
+				"asymmetric home.md:5: Home lists Settings as an exit point, but Settings does not list Home as an entry point",
+				`dangling settings.md:3: Settings: entry from unknown screen "Search"`,
+			},
+		},
+		{
+			name:      "unreachable",
+			screens:   []Screen{home, login, settings, {Name: "Debug", File: "debug.md", Line: 2}},
+			wantRoots: "login",
+			wantIssues: []string{
+				"unreachable debug.md:2: Debug is unreachable: no screen navigates to it",
+			},
+		},
+		{
+			name: "unreachable cycle",
+			screens: []Screen{
+				{Name: "A", File: "a.md", Line: 1, Entries: []Link{{Target: "App launch"}}},
+				{Name: "B", File: "b.md", Line: 1, Exits: []Link{{Target: "C"}}, Entries: []Link{{Target: "C"}}},
+				{Name: "C", File: "c.md", Line: 1, Exits: []Link{{Target: "B"}}, Entries: []Link{{Target: "B"}}},
+			},
+			wantRoots: "a",
+			wantIssues: []string{
+				"unreachable b.md:1: B is unreachable: no entry screen leads to it",
+				"unreachable c.md:1: C is unreachable: no entry screen leads to it",
+			},
+		},
+		{
+			name:      "named roots",
+			screens:   []Screen{{Name: "A", File: "a.md"}, {Name: "B", File: "b.md"}},
+			roots:     []string{"a screen", "b", "Missing"},
+			wantRoots: "a,b",
+			wantIssues: []string{
+				`dangling entry screen "Missing" is not specified`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := Build(tt.screens, tt.roots)
+
+			var roots []string
+			for _, screen := range g.Screens {
+				if g.Roots[Key(screen.Name)] {
+					roots = append(roots, Key(screen.Name))
+				}
+			}
+			if got := strings.Join(roots, ","); got != tt.wantRoots {
+				t.Errorf("roots = %s, want %s", got, tt.wantRoots)
+			}
+
+			var issues []string
+			for _, issue := range g.Issues {
+				issues = append(issues, fmt.Sprintf("%s %s", issue.Kind, issue))
+			}
+			if strings.Join(issues, "\n") != strings.Join(tt.wantIssues, "\n") {
+				t.Errorf("issues =\n%s\nwant\n%s", strings.Join(issues, "\n"), strings.Join(tt.wantIssues, "\n"))
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := Build([]Screen{
+		{Name: "Home", Entries: []Link{{Target: "App launch"}}, Exits: []Link{{Target: `Say "Hi"`, Via: "menu"}, {Target: "Gone"}}},
+		{Name: `Say "Hi"`, Entries: []Link{{Target: "Home", Via: "menu"}}},
+	}, nil)
+
+	mermaid := "flowchart LR\n" +
+		"    s1([\"Home\"])\n" +
+		"    s2[\"Say #quot;Hi#quot;\"]\n" +
+		"    m1[\"Gone\"]:::missing\n" +
+		"    s1 -->|\"menu\"| s2\n" +
+		"    s1 -.-> m1\n" +
+		"    classDef missing stroke-dasharray: 5 5\n"
+	if got := g.Mermaid(); got != mermaid {
+		t.Errorf("Mermaid =\n%s\nwant\n%s", got, mermaid)
+	}
+
+	dot := "digraph screens {\n" +
+		"    rankdir=LR;\n" +
+		"    node [shape=box];\n" +
+		"    s1 [label=\"Home\", peripheries=2];\n" +
+		"    s2 [label=\"Say \\\"Hi\\\"\"];\n" +
+		"    m1 [label=\"Gone\", style=dashed];\n" +
+		"    s1 -> s2 [label=\"menu\"];\n" +
+		"    s1 -> m1 [style=dashed];\n" +
+		"}\n"
+	if got := g.DOT(); got != dot {
+		t.Errorf("DOT =\n%s\nwant\n%s", got, dot)
+	}
+}
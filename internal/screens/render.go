@@ -0,0 +1,113 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nodeIDs assigns diagram identifiers to the screens and to the unknown
+// targets of dangling exits.
+func (g *Graph) nodeIDs() (map[string]string, []string) {
+	ids := make(map[string]string)
+	for i, screen := range g.Screens {
+		ids[Key(screen.Name)] = fmt.Sprintf("s%d", i+1)
+	}
+	var missing []string
+	for _, edge := range g.Edges {
+		if edge.Missing {
+			if _, ok := ids[edge.To]; !ok {
+				missing = append(missing, edge.To)
+				ids[edge.To] = fmt.Sprintf("m%d", len(missing))
+			}
+		}
+	}
+	return ids, missing
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Entry screens are
+// drawn as stadiums, navigation declared by one side only as dotted
+// arrows and unknown targets as dashed nodes.
+func (g *Graph) Mermaid() string {
+	ids, missing := g.nodeIDs()
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, screen := range g.Screens {
+		id, label := ids[Key(screen.Name)], mermaidText(screen.Name)
+		if g.Roots[Key(screen.Name)] {
+			fmt.Fprintf(&b, "    %s([\"%s\"])\n", id, label)
+		} else {
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", id, label)
+		}
+	}
+	for _, name := range missing {
+		fmt.Fprintf(&b, "    %s[\"%s\"]:::missing\n", ids[name], mermaidText(name))
+	}
+
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if !edge.Exit || !edge.Entry {
+			arrow = "-.->"
+		}
+		if edge.Via != "" {
+			fmt.Fprintf(&b, "    %s %s|\"%s\"| %s\n", ids[edge.From], arrow, mermaidText(edge.Via), ids[edge.To])
+		} else {
+			fmt.Fprintf(&b, "    %s %s %s\n", ids[edge.From], arrow, ids[edge.To])
+		}
+	}
+
+	if len(missing) > 0 {
+		b.WriteString("    classDef missing stroke-dasharray: 5 5\n")
+	}
+	return b.String()
+}
+
+// DOT renders the graph in the Graphviz DOT language, with the same
+// conventions as Mermaid: double borders for entry screens, dashed edges
+// for one-sided navigation and dashed nodes for unknown targets.
+func (g *Graph) DOT() string {
+	ids, missing := g.nodeIDs()
+	var b strings.Builder
+	b.WriteString("digraph screens {\n")
+	b.WriteString("    rankdir=LR;\n")
+	b.WriteString("    node [shape=box];\n")
+
+	for _, screen := range g.Screens {
+		attributes := fmt.Sprintf("label=%s", dotText(screen.Name))
+		if g.Roots[Key(screen.Name)] {
+			attributes += ", peripheries=2"
+		}
+		fmt.Fprintf(&b, "    %s [%s];\n", ids[Key(screen.Name)], attributes)
+	}
+	for _, name := range missing {
+		fmt.Fprintf(&b, "    %s [label=%s, style=dashed];\n", ids[name], dotText(name))
+	}
+
+	for _, edge := range g.Edges {
+		var attributes []string
+		if edge.Via != "" {
+			attributes = append(attributes, "label="+dotText(edge.Via))
+		}
+		if !edge.Exit || !edge.Entry {
+			attributes = append(attributes, "style=dashed")
+		}
+		if len(attributes) > 0 {
+			fmt.Fprintf(&b, "    %s -> %s [%s];\n", ids[edge.From], ids[edge.To], strings.Join(attributes, ", "))
+		} else {
+			fmt.Fprintf(&b, "    %s -> %s;\n", ids[edge.From], ids[edge.To])
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaidText escapes text for a quoted Mermaid label.
+func mermaidText(text string) string {
+	return strings.ReplaceAll(text, `"`, "#quot;")
+}
+
+// dotText quotes text as a DOT string.
+func dotText(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,289 @@
+// Package screens reads the screen specifications under specs/ and ties
+// their entry and exit points together into a navigation graph.
+package screens
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+// Screen is a documented screen and the navigation it declares.
+type Screen struct {
+	Name string
+	File string
+	Line int
+	// Entries are the places users come from, Exits where they can go next.
+	Entries []Link
+	Exits   []Link
+	// Calls are the endpoints listed under API Calls.
+	Calls []apispec.Reference
+}
+
+// Link is one declared navigation step.
+type Link struct {
+	Target string
+	Via    string
+	Line   int
+}
+
+// Key identifies a screen name regardless of case, spacing and a
+// trailing "screen", so that "Login", "login screen" and "LOGIN" match.
+func Key(name string) string {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	name = strings.TrimSuffix(name, " screen")
+	name = strings.TrimSuffix(name, " page")
+	return name
+}
+
+type navKind int
+
+const (
+	navNone navKind = iota
+	navAny
+	navEntry
+	navExit
+)
+
+var (
+	screenTitle      = regexp.MustCompile(`(?i)\s+screen(\s+spec(ification)?)?$|\s+spec(ification)?$`)
+	singleScreenFile = regexp.MustCompile(`(?i)\bscreen\s+spec(ification)?$`)
+	screenListFile   = regexp.MustCompile(`(?i)^screens?(\s+spec(ification)?s)?$`)
+	markdownLink     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	fromPrefix       = regexp.MustCompile(`(?i)^(?:comes?\s+)?from\b\s*`)
+	toPrefix         = regexp.MustCompile(`(?i)^(?:goes?\s+|go\s+|navigates?\s+)?to\b\s*`)
+	viaSeparator     = regexp.MustCompile(`(?i)\s+(?:via|through)\s+|\s+[-–—]\s+|\s*:\s*`)
+	viaPrefix        = regexp.MustCompile(`(?i)^(?:via|through)\s+`)
+	targetSeparator  = regexp.MustCompile(`(?i)\s*(?:,|;|\s+or\s+)\s*`)
+)
+
+func classifyHeading(title string) navKind {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimSuffix(title, ":"))) {
+	case "navigation", "navigation flow":
+		return navAny
+	case "entry points", "entry point", "navigation from", "from", "entered from":
+		return navEntry
+	case "exit points", "exit point", "navigation to", "to", "next screens":
+		return navExit
+	}
+	return navNone
+}
+
+func isAPICalls(title string) bool {
+	switch strings.ToLower(strings.TrimSpace(strings.TrimSuffix(title, ":"))) {
+	case "api calls", "api", "api endpoints", "endpoints used":
+		return true
+	}
+	return false
+}
+
+// Parse returns the screens specified in content: the document itself
+// when its heading names a screen specification or it lives under
+// specs/screens/, each second-level section of a "Screen Specifications"
+// list, and any section with a Navigation, Entry Points or Exit Points
+// subsection.
+func Parse(file, content string) []Screen {
+	blocks := markdown.Blocks(content)
+
+	starts := make(map[int]bool)
+	for i, block := range blocks {
+		if block.Kind != markdown.Heading {
+			continue
+		}
+		if block.Level == 1 {
+			inScreensDir := strings.Contains(filepath.ToSlash(file), "/screens/")
+			switch {
+			case screenListFile.MatchString(strings.TrimSpace(block.Title)):
+				for j := i + 1; j < len(blocks) && !(blocks[j].Kind == markdown.Heading && blocks[j].Level == 1); j++ {
+					// "Screen Index" sections link to specs/screens/ and are not screens
+					if blocks[j].Kind == markdown.Heading && blocks[j].Level == 2 && !strings.HasSuffix(strings.ToLower(blocks[j].Title), "index") {
+						starts[j] = true
+					}
+				}
+				continue
+			case singleScreenFile.MatchString(block.Title) || inScreensDir:
+				starts[i] = true
+				continue
+			}
+		}
+
+		// A navigation section belongs to the screen enclosing it
+		if classifyHeading(block.Title) == navNone {
+			continue
+		}
+		parent := enclosing(blocks, i)
+		if parent >= 0 && classifyHeading(blocks[parent].Title) != navNone {
+			continue
+		}
+		if parent >= 0 && !covered(blocks, starts, parent) {
+			starts[parent] = true
+		}
+	}
+
+	indexes := make([]int, 0, len(starts))
+	for i := range starts {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	var screens []Screen
+	for _, i := range indexes {
+		screens = append(screens, parseScreen(file, blocks, i))
+	}
+	return screens
+}
+
+// enclosing returns the index of the heading that contains the heading at i.
+func enclosing(blocks []markdown.Block, i int) int {
+	for j := i - 1; j >= 0; j-- {
+		if blocks[j].Kind == markdown.Heading && blocks[j].Level < blocks[i].Level {
+			return j
+		}
+	}
+	return -1
+}
+
+// covered reports whether the heading at i is, or is inside, a screen.
+func covered(blocks []markdown.Block, starts map[int]bool, i int) bool {
+	for ; i >= 0; i = enclosing(blocks, i) {
+		if starts[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func parseScreen(file string, blocks []markdown.Block, start int) Screen {
+	heading := blocks[start]
+	screen := Screen{
+		Name: strings.TrimSpace(screenTitle.ReplaceAllString(cleanText(heading.Title), "")),
+		File: file,
+		Line: heading.StartLine,
+	}
+
+	kind, kindLevel := navNone, 0
+	callsLevel := 0
+	for _, block := range blocks[start+1:] {
+		if block.Kind == markdown.Heading {
+			if block.Level <= heading.Level {
+				break
+			}
+			if isAPICalls(block.Title) {
+				callsLevel = block.Level
+			} else if block.Level <= callsLevel {
+				callsLevel = 0
+			}
+			if k := classifyHeading(block.Title); k != navNone {
+				if kind == navNone || block.Level <= kindLevel || kind == navAny {
+					kind, kindLevel = k, block.Level
+				}
+			} else if block.Level <= kindLevel {
+				kind, kindLevel = navNone, 0
+			}
+			continue
+		}
+		if callsLevel > 0 {
+			for _, call := range apispec.Extract(file, block.Text) {
+				call.Line += block.StartLine - 1
+				screen.Calls = append(screen.Calls, call)
+			}
+			continue
+		}
+		if kind == navNone || block.Kind != markdown.List {
+			continue
+		}
+
+		for i, line := range strings.Split(strings.TrimRight(block.Text, "\n"), "\n") {
+			marker := markdown.ListItemPattern.FindStringIndex(line)
+			if marker == nil {
+				continue
+			}
+			entry, links := parseItem(cleanText(line[marker[1]:]), kind, block.StartLine+i)
+			if entry {
+				screen.Entries = append(screen.Entries, links...)
+			} else {
+				screen.Exits = append(screen.Exits, links...)
+			}
+		}
+	}
+	return screen
+}
+
+// parseItem reads a navigation item such as "From Home: via the menu",
+// "To: Settings, Profile" or, under Entry Points, "Login via redirect".
+func parseItem(item string, kind navKind, line int) (bool, []Link) {
+	entry := kind == navEntry
+	switch {
+	case fromPrefix.MatchString(item):
+		entry = true
+		item = fromPrefix.ReplaceAllString(item, "")
+	case toPrefix.MatchString(item):
+		entry = false
+		item = toPrefix.ReplaceAllString(item, "")
+	case kind == navAny:
+		// Navigation items must say which way they go
+		return false, nil
+	}
+
+	// "From: A, B" lists targets, "From A: via ..." describes one
+	if strings.HasPrefix(item, ":") {
+		var links []Link
+		for _, target := range targetSeparator.Split(strings.TrimSpace(item[1:]), -1) {
+			if target = strings.Trim(target, " ."); target != "" {
+				links = append(links, Link{Target: target, Line: line})
+			}
+		}
+		return entry, links
+	}
+
+	target, via := item, ""
+	if loc := viaSeparator.FindStringIndex(item); loc != nil {
+		target, via = item[:loc[0]], viaPrefix.ReplaceAllString(strings.TrimSpace(item[loc[1]:]), "")
+	}
+	target = strings.Trim(target, " .")
+	if target == "" {
+		return entry, nil
+	}
+	return entry, []Link{{Target: target, Via: strings.TrimSuffix(via, "."), Line: line}}
+}
+
+// cleanText drops inline Markdown: links keep their text, emphasis and
+// code markers are removed.
+func cleanText(text string) string {
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("**", "", "__", "", "`", "").Replace(text)
+	return strings.TrimSpace(text)
+}
+
+// DocFiles returns the Markdown files under dir/specs that may specify
+// screens.
+func DocFiles(dir string) []string {
+	var files []string
+	filepath.Walk(filepath.Join(dir, "specs"), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && strings.EqualFold(filepath.Ext(path), ".md") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files
+}
+
+// ParseFiles reads files and returns the screens they specify.
+func ParseFiles(files []string) ([]Screen, error) {
+	var screens []Screen
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		screens = append(screens, Parse(file, string(content))...)
+	}
+	return screens, nil
+}
@@ -0,0 +1,119 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// describe renders screens as "Name: <entries> | <exits>" with links as
+// "target(via)@line".
+func describe(screens []Screen) []string {
+	links := func(links []Link) string {
+		var parts []string
+		for _, link := range links {
+			part := link.Target
+			if link.Via != "" {
+				part += "(" + link.Via + ")"
+			}
+			parts = append(parts, fmt.Sprintf("%s@%d", part, link.Line))
+		}
+		return strings.Join(parts, ", ")
+	}
+
+	var got []string
+	for _, screen := range screens {
+		got = append(got, fmt.Sprintf("%s: %s | %s", screen.Name, links(screen.Entries), links(screen.Exits)))
+	}
+	return got
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{
+			name: "screen template",
+			file: "specs/login.md",
+			content: `# Login Screen Specification
+
+## Entry Points
+- App launch
+- From Settings via **Sign out**
+
+## Exit Points
+- To Home: after signing in
+- [Forgot Password](forgot.md) - via the link
+`,
+			want: []string{"Login: App launch@4, Settings(Sign out)@5 | Home(after signing in)@8, Forgot Password(the link)@9"},
+		},
+		{
+			name: "screen list",
+			file: "specs/screens.md",
+			content: `# Screen Specifications
+
+## Screen Index
+- [Home](screens/home.md)
+
+## Home
+
+### Navigation
+- From: Login, Signup
+- To: Settings or Profile
+- Settings page without a direction
+
+## Settings
+`,
+			want: []string{
+				"Home: Login@9, Signup@9 | Settings@10, Profile@10",
+				"Settings:  | ",
+			},
+		},
+		{
+			name:    "file under specs/screens",
+			file:    "specs/screens/profile.md",
+			content: "# Profile\n\n## Navigation From\n- Home\n",
+			want:    []string{"Profile: Home@4 | "},
+		},
+		{
+			name: "section with navigation",
+			file: "specs/flows.md",
+			content: `# Flows
+
+## Checkout
+
+### Navigation To
+- Receipt
+
+## Notes
+
+Nothing to see.
+`,
+			want: []string{"Checkout:  | Receipt@6"},
+		},
+		{
+			name:    "no screens",
+			file:    "specs/api.md",
+			content: "# API\n\n## GET /users\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describe(Parse(tt.file, tt.content))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("screens =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	for _, name := range []string{"Login", "login screen", "LOGIN", " Login  Page"} {
+		if got := Key(name); got != "login" {
+			t.Errorf("Key(%q) = %q, want login", name, got)
+		}
+	}
+}
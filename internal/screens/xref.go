@@ -0,0 +1,60 @@
+package screens
+
+import "github.com/claude-code/claude-doc-structure/internal/apispec"
+
+// Call is an API call listed by a screen, with the endpoint documenting
+// it when there is one.
+type Call struct {
+	Screen   string
+	Ref      apispec.Reference
+	Endpoint *apispec.Reference
+}
+
+// CrossReference links the screens' API calls to documented endpoints.
+type CrossReference struct {
+	Calls []Call
+	// Unused are the documented endpoints no screen calls.
+	Unused []apispec.Reference
+}
+
+// Undocumented returns the calls to endpoints that are not documented.
+func (x CrossReference) Undocumented() []Call {
+	var calls []Call
+	for _, call := range x.Calls {
+		if call.Endpoint == nil {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// LinkCalls matches the API calls of screens against documented endpoints by
+// method and path, with path parameters matching across notations.
+func LinkCalls(screens []Screen, documented []apispec.Reference) CrossReference {
+	byKey := make(map[string]int, len(documented))
+	for i, ref := range documented {
+		if _, ok := byKey[ref.Key()]; !ok {
+			byKey[ref.Key()] = i
+		}
+	}
+
+	var x CrossReference
+	used := make(map[int]bool)
+	for _, screen := range screens {
+		for _, ref := range screen.Calls {
+			call := Call{Screen: screen.Name, Ref: ref}
+			if i, ok := byKey[ref.Key()]; ok {
+				call.Endpoint = &documented[i]
+				used[i] = true
+			}
+			x.Calls = append(x.Calls, call)
+		}
+	}
+
+	for i, ref := range documented {
+		if !used[i] && byKey[ref.Key()] == i {
+			x.Unused = append(x.Unused, ref)
+		}
+	}
+	return x
+}
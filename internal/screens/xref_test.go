@@ -0,0 +1,98 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/apispec"
+)
+
+func TestParseAPICalls(t *testing.T) {
+	content := `# Orders Screen Specification
+
+## API Calls
+- ` + "`GET /api/orders`" + ` to list orders
+- POST /api/orders/:id/cancel
+
+## Exit Points
+- To Order Details via GET /api/orders/{id}
+`
+	screens := Parse("specs/orders.md", content)
+	if len(screens) != 1 {
+		t.Fatalf("found %d screens, want 1", len(screens))
+	}
+
+	var got []string
+	for _, call := range screens[0].Calls {
+		got = append(got, call.String())
+	}
+	want := "GET /api/orders (specs/orders.md:4), POST /api/orders/:id/cancel (specs/orders.md:5)"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("calls = %s, want %s", strings.Join(got, ", "), want)
+	}
+}
+
+func TestLinkCalls(t *testing.T) {
+	documented := []apispec.Reference{
+		{Method: "GET", Path: "/api/orders/{orderId}", File: "api.md", Line: 10},
+		{Method: "GET", Path: "/api/orders/:id", File: "api/orders.md", Line: 3},
+		{Method: "DELETE", Path: "/api/orders/{id}", File: "api.md", Line: 20},
+		{Method: "GET", Path: "/api/health", File: "api.md", Line: 30},
+	}
+
+	tests := []struct {
+		name             string
+		calls            []apispec.Reference
+		wantLinks        []string
+		wantUndocumented []string
+		wantUnused       []string
+	}{
+		{
+			name:       "path parameters match across notations",
+			calls:      []apispec.Reference{{Method: "GET", Path: "/api/orders/<id>"}, {Method: "DELETE", Path: "/api/orders/[id]?force=1"}},
+			wantLinks:  []string{"GET /api/orders/<id> → api.md:10", "DELETE /api/orders/[id]?force=1 → api.md:20"},
+			wantUnused: []string{"GET /api/health"},
+		},
+		{
+			name:             "method must match",
+			calls:            []apispec.Reference{{Method: "POST", Path: "/api/orders/{id}"}},
+			wantUndocumented: []string{"POST /api/orders/{id}"},
+			wantUnused:       []string{"GET /api/orders/{orderId}", "DELETE /api/orders/{id}", "GET /api/health"},
+		},
+		{
+			name:       "no calls",
+			wantUnused: []string{"GET /api/orders/{orderId}", "DELETE /api/orders/{id}", "GET /api/health"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := LinkCalls([]Screen{{Name: "Orders", Calls: tt.calls}}, documented)
+
+			var links, undocumented, unused []string
+			for _, call := range x.Calls {
+				if call.Screen != "Orders" {
+					t.Errorf("call %s belongs to %q", call.Ref.Key(), call.Screen)
+				}
+				if call.Endpoint != nil {
+					links = append(links, fmt.Sprintf("%s %s → %s:%d", call.Ref.Method, call.Ref.Path, call.Endpoint.File, call.Endpoint.Line))
+				}
+			}
+			for _, call := range x.Undocumented() {
+				undocumented = append(undocumented, call.Ref.Method+" "+call.Ref.Path)
+			}
+			for _, ref := range x.Unused {
+				unused = append(unused, ref.Method+" "+ref.Path)
+			}
+
+			check := func(what string, got, want []string) {
+				if strings.Join(got, "\n") != strings.Join(want, "\n") {
+					t.Errorf("%s = %v, want %v", what, got, want)
+				}
+			}
+			check("links", links, tt.wantLinks)
+			check("undocumented", undocumented, tt.wantUndocumented)
+			check("unused", unused, tt.wantUnused)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Package section reads and edits the sections of Markdown documents by
+// heading path, leaving the rest of the document byte for byte as it was.
+//
+// A heading path lists heading titles from the outside in, separated by
+// " > ", such as "Architecture > Key Components". It may leave out outer
+// headings such as the document title, and an element written as a heading
+// ("## Setup") only matches headings of that level. Titles are compared
+// ignoring case.
+package section
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+// PathSeparator separates the titles of a heading path.
+const PathSeparator = " > "
+
+var (
+	// ErrNotFound is returned when no heading matches a path.
+	ErrNotFound = errors.New("section not found")
+	// ErrAmbiguous is returned when several headings match a path.
+	ErrAmbiguous = errors.New("ambiguous section")
+)
+
+// Section is the position of a section in a document. Its body runs from
+// the end of the heading line to the next heading of the same or a higher
+// level, and includes subsections.
+type Section struct {
+	Level int
+	Title string
+	// Path holds the titles of the heading and its enclosing headings.
+	Path []string
+	// Start is the offset of the heading, BodyStart the offset after the
+	// heading line and End the offset after the body.
+	Start     int
+	BodyStart int
+	End       int
+	Line      int
+}
+
+// Name returns the full heading path of the section.
+func (s Section) Name() string {
+	return strings.Join(s.Path, PathSeparator)
+}
+
+// ParsePath splits a heading path into its elements.
+func ParsePath(path string) []string {
+	var elements []string
+	for _, element := range strings.Split(path, PathSeparator) {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// All returns the sections of content in document order. Headings inside
+// fenced code are ignored.
+func All(content string) []Section {
+	blocks := markdown.Blocks(content)
+	var sections []Section
+	var path markdown.HeadingPath
+	for i, block := range blocks {
+		if block.Kind != markdown.Heading {
+			continue
+		}
+		path.Enter(block.Level, block.Title)
+		end := len(content)
+		for _, next := range blocks[i+1:] {
+			if next.Kind == markdown.Heading && next.Level <= block.Level {
+				end = next.Start
+				break
+			}
+		}
+		sections = append(sections, Section{
+			Level:     block.Level,
+			Title:     block.Title,
+			Path:      path.Titles(),
+			Start:     block.Start,
+			BodyStart: block.End,
+			End:       end,
+			Line:      block.StartLine,
+		})
+	}
+	return sections
+}
+
+// Find returns the section matching path. The path must match the
+// innermost headings of the section's full path.
+func Find(content string, path []string) (Section, error) {
+	if len(path) == 0 {
+		return Section{}, fmt.Errorf("%w: empty heading path", ErrNotFound)
+	}
+	all := All(content)
+	levels := levelsOf(all)
+
+	var matches []Section
+	for _, section := range all {
+		if matchPath(section, levels[section.Start], path) {
+			matches = append(matches, section)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Section{}, fmt.Errorf("%w: %s", ErrNotFound, strings.Join(path, PathSeparator))
+	case 1:
+		return matches[0], nil
+	}
+	var names []string
+	for _, match := range matches {
+		names = append(names, fmt.Sprintf("%s (line %d)", match.Name(), match.Line))
+	}
+	return Section{}, fmt.Errorf("%w: %s matches %s", ErrAmbiguous, strings.Join(path, PathSeparator), strings.Join(names, ", "))
+}
+
+// levelsOf returns the heading levels along each section's path, keyed by
+// the section's offset.
+func levelsOf(sections []Section) map[int][]int {
+	levels := make(map[int][]int, len(sections))
+	var stack []int
+	for _, section := range sections {
+		for len(stack) > 0 && stack[len(stack)-1] >= section.Level {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, section.Level)
+		levels[section.Start] = append([]int(nil), stack...)
+	}
+	return levels
+}
+
+func matchPath(section Section, levels []int, path []string) bool {
+	if len(path) > len(section.Path) {
+		return false
+	}
+	offset := len(section.Path) - len(path)
+	for i, element := range path {
+		title, level := element, 0
+		if l, t, ok := markdown.ParseHeading(element); ok {
+			title, level = t, l
+		}
+		if !strings.EqualFold(section.Path[offset+i], title) {
+			return false
+		}
+		if level != 0 && levels[offset+i] != level {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the body of the section at path, without the blank lines
+// around it.
+func Get(content string, path []string) (string, error) {
+	section, err := Find(content, path)
+	if err != nil {
+		return "", err
+	}
+	body := strings.Trim(content[section.BodyStart:section.End], "\r\n")
+	if body == "" {
+		return "", nil
+	}
+	return body + "\n", nil
+}
+
+// Set replaces the body of the section at path, keeping its heading and
+// the blank line conventions of the document.
+func Set(content string, path []string, body string) (string, error) {
+	section, err := Find(content, path)
+	if err != nil {
+		return "", err
+	}
+	return replaceBody(content, section, body), nil
+}
+
+func replaceBody(content string, section Section, body string) string {
+	body = strings.Trim(body, "\r\n")
+	if body != "" {
+		body += "\n"
+		// Keep the document's spacing after the heading
+		if strings.HasPrefix(content[section.BodyStart:section.End], "\n") ||
+			strings.HasPrefix(content[section.BodyStart:section.End], "\r\n") ||
+			section.BodyStart == section.End {
+			body = "\n" + body
+		}
+	}
+	if section.End < len(content) {
+		body += "\n"
+	}
+	prefix := content[:section.BodyStart]
+	if !strings.HasSuffix(prefix, "\n") {
+		prefix += "\n"
+	}
+	return prefix + body + content[section.End:]
+}
+
+// Create adds a section at path with the given body, at the end of the
+// section named by the rest of the path and one level below it, or at the
+// end of the document when the path has a single element. The last
+// element may be written as a heading to choose its level.
+func Create(content string, path []string, body string) (string, error) {
+	if len(path) == 0 {
+		return "", fmt.Errorf("%w: empty heading path", ErrNotFound)
+	}
+	level, title := 0, path[len(path)-1]
+	if l, t, ok := markdown.ParseHeading(title); ok {
+		level, title = l, t
+	}
+
+	at := len(content)
+	if len(path) > 1 {
+		parent, err := Find(content, path[:len(path)-1])
+		if err != nil {
+			return "", err
+		}
+		at = parent.End
+		if level == 0 {
+			level = min(parent.Level+1, 6)
+		}
+	} else if level == 0 {
+		level = 1
+		for _, section := range All(content) {
+			if section.Level == 1 {
+				level = 2
+				break
+			}
+		}
+	}
+
+	text := strings.Repeat("#", level) + " " + title + "\n"
+	if body = strings.Trim(body, "\r\n"); body != "" {
+		text += "\n" + body + "\n"
+	}
+	return insert(content, at, text), nil
+}
+
+// Append adds text at the end of the body of the section at path, after
+// its subsections. List items continue a list the section ends with;
+// anything else starts a new paragraph.
+func Append(content string, path []string, text string) (string, error) {
+	section, err := Find(content, path)
+	if err != nil {
+		return "", err
+	}
+	text = strings.Trim(text, "\r\n")
+	if text == "" {
+		return content, nil
+	}
+
+	// Insert after the last non-blank line of the section
+	at := section.BodyStart + len(strings.TrimRight(content[section.BodyStart:section.End], " \t\r\n"))
+	if at == section.BodyStart {
+		return replaceBody(content, section, text), nil
+	}
+	lastLine := content[strings.LastIndexByte(content[:at], '\n')+1 : at]
+	separator := "\n\n"
+	if markdown.ListItemPattern.MatchString(lastLine) && markdown.ListItemPattern.MatchString(text) {
+		separator = "\n"
+	}
+	return content[:at] + separator + text + content[at:], nil
+}
+
+// Delete removes the section at path with its subsections.
+func Delete(content string, path []string) (string, error) {
+	section, err := Find(content, path)
+	if err != nil {
+		return "", err
+	}
+	rest := content[section.End:]
+	if rest == "" {
+		// Do not leave the blank line that separated the section behind
+		return strings.TrimRight(content[:section.Start], "\r\n") + "\n", nil
+	}
+	return content[:section.Start] + rest, nil
+}
+
+// insert places a section's text at offset at, separated from the text
+// before and after it by a blank line.
+func insert(content string, at int, text string) string {
+	before, after := content[:at], content[at:]
+	if before != "" {
+		before = strings.TrimRight(before, "\r\n") + "\n\n"
+	}
+	if after != "" {
+		text += "\n"
+	}
+	return before + text + after
+}
@@ -0,0 +1,202 @@
+package section
+
+import (
+	"errors"
+	"testing"
+)
+
+const doc = `# Guide
+
+Intro.
+
+## Setup
+
+Install it.
+
+### Linux
+
+apt install it
+
+## Usage
+
+- one
+
+` + "```markdown\n## Setup\n```" + `
+
+### Linux
+
+Run it.
+`
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantName string
+		wantLine int
+		wantErr  error
+	}{
+		{"Setup", "Guide > Setup", 5, nil},
+		{"guide > usage > linux", "Guide > Usage > Linux", 21, nil},
+		{"Setup > Linux", "Guide > Setup > Linux", 9, nil},
+		{"### Linux", "", 0, ErrAmbiguous},
+		{"## Linux", "", 0, ErrNotFound},
+		{"# Guide > ## Usage", "Guide > Usage", 13, nil},
+		{"Guide > Linux", "", 0, ErrNotFound},
+		{"Missing", "", 0, ErrNotFound},
+		{"", "", 0, ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			section, err := Find(doc, ParsePath(tt.path))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if section.Name() != tt.wantName || section.Line != tt.wantLine {
+				t.Errorf("found %s on line %d, want %s on line %d", section.Name(), section.Line, tt.wantName, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"Setup", "Install it.\n\n### Linux\n\napt install it\n"},
+		{"Setup > Linux", "apt install it\n"},
+		{"Usage > Linux", "Run it.\n"},
+	}
+	for _, tt := range tests {
+		got, err := Get(doc, ParsePath(tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Get(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestEdit(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		edit    func(content string) (string, error)
+		want    string
+	}{
+		{
+			name:    "set nested body",
+			content: doc,
+			edit: func(content string) (string, error) {
+				return Set(content, ParsePath("Setup > Linux"), "snap install it\n")
+			},
+			want: "# Guide\n\nIntro.\n\n## Setup\n\nInstall it.\n\n### Linux\n\nsnap install it\n\n## Usage\n\n- one\n\n```markdown\n## Setup\n```\n\n### Linux\n\nRun it.\n",
+		},
+		{
+			name:    "set last section",
+			content: "# A\n\n## B\n\nold\n",
+			edit: func(content string) (string, error) {
+				return Set(content, ParsePath("B"), "new")
+			},
+			want: "# A\n\n## B\n\nnew\n",
+		},
+		{
+			name:    "set heading without spacing",
+			content: "## A\nold\n## B\n",
+			edit: func(content string) (string, error) {
+				return Set(content, ParsePath("A"), "new")
+			},
+			want: "## A\nnew\n\n## B\n",
+		},
+		{
+			name:    "clear body",
+			content: "## A\n\nold\n\n## B\n",
+			edit: func(content string) (string, error) {
+				return Set(content, ParsePath("A"), "")
+			},
+			want: "## A\n\n## B\n",
+		},
+		{
+			name:    "append after subsections",
+			content: doc,
+			edit: func(content string) (string, error) {
+				return Append(content, ParsePath("Setup"), "See also the FAQ.")
+			},
+			want: "# Guide\n\nIntro.\n\n## Setup\n\nInstall it.\n\n### Linux\n\napt install it\n\nSee also the FAQ.\n\n## Usage\n\n- one\n\n```markdown\n## Setup\n```\n\n### Linux\n\nRun it.\n",
+		},
+		{
+			name:    "append list item",
+			content: "## Todo\n\n- one\n\n## Done\n",
+			edit: func(content string) (string, error) {
+				return Append(content, ParsePath("Todo"), "- two")
+			},
+			want: "## Todo\n\n- one\n- two\n\n## Done\n",
+		},
+		{
+			name:    "append to empty section",
+			content: "## Todo\n\n## Done\n",
+			edit: func(content string) (string, error) {
+				return Append(content, ParsePath("Todo"), "- one")
+			},
+			want: "## Todo\n\n- one\n\n## Done\n",
+		},
+		{
+			name:    "delete nested section",
+			content: doc,
+			edit: func(content string) (string, error) {
+				return Delete(content, ParsePath("Setup > Linux"))
+			},
+			want: "# Guide\n\nIntro.\n\n## Setup\n\nInstall it.\n\n## Usage\n\n- one\n\n```markdown\n## Setup\n```\n\n### Linux\n\nRun it.\n",
+		},
+		{
+			name:    "delete last section",
+			content: doc,
+			edit: func(content string) (string, error) {
+				return Delete(content, ParsePath("Usage"))
+			},
+			want: "# Guide\n\nIntro.\n\n## Setup\n\nInstall it.\n\n### Linux\n\napt install it\n",
+		},
+		{
+			name:    "create subsection",
+			content: "# Guide\n\n## Setup\n\nInstall it.\n\n## Usage\n",
+			edit: func(content string) (string, error) {
+				return Create(content, ParsePath("Setup > macOS"), "brew install it")
+			},
+			want: "# Guide\n\n## Setup\n\nInstall it.\n\n### macOS\n\nbrew install it\n\n## Usage\n",
+		},
+		{
+			name:    "create top-level section",
+			content: "# Guide\n\nIntro.\n",
+			edit: func(content string) (string, error) {
+				return Create(content, ParsePath("FAQ"), "")
+			},
+			want: "# Guide\n\nIntro.\n\n## FAQ\n",
+		},
+		{
+			name:    "crlf outside the edited body is kept",
+			content: "## A\r\n\r\nold\r\n\r\n## B\r\n\r\nkept\r\n",
+			edit: func(content string) (string, error) {
+				return Set(content, ParsePath("A"), "new")
+			},
+			want: "## A\r\n\nnew\n\n## B\r\n\r\nkept\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.edit(tt.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("edited =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package splitter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+// SplitAll splits every oversized Markdown file found in paths, which may
+// be files, directories (searched recursively) or glob patterns. Files at
+// or under the threshold are left alone. Each file is split into a
+// directory named after it, under OutputDir if set or next to the file
+// otherwise, and one manifest describing all sections is written to
+// OutputDir or the common directory of the inputs.
+func (s *Splitter) SplitAll(paths []string, method SplitMethod) error {
+	files, err := FindMarkdown(paths)
+	if err != nil {
+		return err
+	}
+
+	root := commonDir(inputRoots(paths))
+	base := s.OutputDir
+	if base == "" {
+		base = root
+	}
+
+	previous, err := loadManifest(base)
+	if err != nil {
+		return err
+	}
+
+	// Skip the sections and indexes written by earlier runs
+	generated := make(map[string]bool)
+	if previous != nil {
+		for _, entry := range previous.Sections {
+			dir := filepath.Dir(filepath.Join(base, filepath.FromSlash(entry.Filename)))
+			if filepath.Clean(dir) != filepath.Clean(base) {
+				generated[filepath.Clean(dir)] = true
+			}
+		}
+	}
+
+	manifest := Manifest{
+		Version: manifestVersion,
+		Source:  filepath.ToSlash(root),
+		Method:  method.String(),
+	}
+	var sections []Section
+	count := 0
+
+	// Keep the sections of documents outside this run's inputs; those of
+	// deleted documents are orphaned
+	inputs := make(map[string]bool, len(files))
+	for _, file := range files {
+		inputs[filepath.ToSlash(file)] = true
+	}
+	var records, orphaned []chunkRecord
+	if previous != nil {
+		records, err = loadChunks(base)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if inputs[record.Source] {
+				continue
+			}
+			if _, err := os.Stat(filepath.FromSlash(record.Source)); err != nil {
+				orphaned = append(orphaned, record)
+				continue
+			}
+			manifest.Sections = append(manifest.Sections, record.ManifestEntry)
+			sections = append(sections, Section{Title: record.Title, Content: record.Content})
+		}
+	}
+
+	splitFiles := make(map[string]bool)
+
+	for _, file := range files {
+		if generated[filepath.Clean(filepath.Dir(file))] {
+			continue
+		}
+
+		oversized, err := s.oversized(file)
+		if err != nil {
+			return err
+		}
+		if !oversized {
+			continue
+		}
+
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			rel = filepath.Base(file)
+		}
+
+		child := *s
+		child.InputFile = file
+		child.OutputDir = filepath.Join(base, strings.TrimSuffix(rel, filepath.Ext(rel)))
+		child.ManifestDir = base
+
+		part, partSections, err := child.split(method)
+		if err != nil {
+			return fmt.Errorf("failed to split %s: %w", file, err)
+		}
+		manifest.Sections = append(manifest.Sections, part.Sections...)
+		sections = append(sections, partSections...)
+		splitFiles[filepath.ToSlash(file)] = true
+		count++
+	}
+
+	// Inputs that are no longer oversized leave their sections behind
+	for _, record := range records {
+		if inputs[record.Source] && !splitFiles[record.Source] {
+			orphaned = append(orphaned, record)
+		}
+	}
+	if err := s.removeOrphans(base, orphaned); err != nil {
+		return err
+	}
+
+	if count > 0 || previous != nil {
+		if err := s.Output.MkdirAll(base); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		owned := s.ownedOutput()
+		manifestSplitter := *s
+		manifestSplitter.OutputDir = base
+		manifestSplitter.ManifestDir = ""
+		if err := manifestSplitter.writeManifest(manifest, sections, owned); err != nil {
+			return err
+		}
+	}
+
+	s.OnEvent.Emit(events.Event{Kind: events.SplitFinished, Path: base, Count: count})
+	return nil
+}
+
+// removeOrphans deletes the section files of records that are no longer
+// part of the split, along with the index and directory of a document
+// whose sections are all gone. Files edited since they were generated are
+// reported as conflicts and kept unless Force is set.
+func (s *Splitter) removeOrphans(base string, records []chunkRecord) error {
+	owned := s.ownedOutput()
+	var dirs []string
+	kept := make(map[string]bool)
+	for _, record := range records {
+		filePath := filepath.Join(base, filepath.FromSlash(record.Filename))
+		dir := filepath.Dir(filePath)
+		if len(dirs) == 0 || dirs[len(dirs)-1] != dir {
+			dirs = append(dirs, dir)
+		}
+
+		existing, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+
+		if !generatedBy(record.ManifestEntry, existing) && !s.Output.Force {
+			s.OnEvent.Emit(events.Event{Kind: events.FileConflict, Path: filePath})
+			kept[dir] = true
+			continue
+		}
+		if err := owned.Remove(filePath); err != nil {
+			return err
+		}
+		if !owned.DryRun {
+			s.OnEvent.Emit(events.Event{Kind: events.FileRemoved, Path: filePath})
+		}
+	}
+
+	for _, dir := range dirs {
+		if kept[dir] || filepath.Clean(dir) == filepath.Clean(base) {
+			continue
+		}
+		index := filepath.Join(dir, IndexFile)
+		if _, err := os.Stat(index); err == nil {
+			if err := owned.Remove(index); err != nil {
+				return err
+			}
+			if !owned.DryRun {
+				s.OnEvent.Emit(events.Event{Kind: events.FileRemoved, Path: index})
+			}
+		}
+		if !owned.DryRun {
+			// Only succeeds when nothing else was put there
+			os.Remove(dir)
+		}
+	}
+	return nil
+}
+
+// oversized reports whether file exceeds the split threshold.
+func (s *Splitter) oversized(file string) (bool, error) {
+	if s.ThresholdTokens > 0 {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		return markdown.EstimateTokens(string(content)) > s.ThresholdTokens, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+	return info.Size() > s.ThresholdKB*1024, nil
+}
+
+// FindMarkdown expands paths into a sorted list of Markdown files. Paths
+// may be files, directories, which are searched recursively skipping
+// hidden directories, or glob patterns.
+func FindMarkdown(paths []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var files []string
+	add := func(path string) {
+		path = filepath.Clean(path)
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, path := range paths {
+		matches := []string{path}
+		if hasGlobMeta(path) {
+			var err error
+			matches, err = filepath.Glob(path)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %w", path, err)
+			}
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+
+			if !info.IsDir() {
+				if isMarkdown(match) || !hasGlobMeta(path) {
+					add(match)
+				}
+				continue
+			}
+
+			err = filepath.Walk(match, func(p string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if info.IsDir() {
+					if p != match && strings.HasPrefix(info.Name(), ".") {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if isMarkdown(p) {
+					add(p)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func isMarkdown(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".md")
+}
+
+func hasGlobMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// WatchRoots returns the directories to watch for changes to the files
+// SplitAll would find in paths.
+func WatchRoots(paths []string) []string {
+	return inputRoots(paths)
+}
+
+// inputRoots returns the directory each input path refers to: the path
+// itself for directories, the parent for files and the part of a glob
+// pattern before its first wildcard.
+func inputRoots(paths []string) []string {
+	roots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if hasGlobMeta(path) {
+			dir := filepath.Dir(path)
+			for hasGlobMeta(dir) {
+				dir = filepath.Dir(dir)
+			}
+			roots = append(roots, dir)
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			roots = append(roots, path)
+		} else {
+			roots = append(roots, filepath.Dir(path))
+		}
+	}
+	return roots
+}
+
+// commonDir returns the deepest directory containing all dirs.
+func commonDir(dirs []string) string {
+	if len(dirs) == 0 {
+		return "."
+	}
+
+	common := filepath.Clean(dirs[0])
+	for _, dir := range dirs[1:] {
+		dir = filepath.Clean(dir)
+		for common != "." && common != string(filepath.Separator) &&
+			dir != common && !strings.HasPrefix(dir, common+string(filepath.Separator)) {
+			common = filepath.Dir(common)
+		}
+	}
+	return common
+}
@@ -0,0 +1,115 @@
+package splitter
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+func newBatchSplitter(t *testing.T, onEvent events.Handler) *Splitter {
+	t.Helper()
+
+	s := New("", "", "")
+	s.ThresholdTokens = 50
+	s.Output = &output.Writer{Log: io.Discard}
+	s.OnEvent = onEvent
+	return s
+}
+
+func TestSplitAllRemovesOrphanedSections(t *testing.T) {
+	long := "# Guide\n\n## Install\n\n" + strings.Repeat("install step\n", 40) +
+		"\n## Usage\n\n" + strings.Repeat("usage note\n", 40)
+
+	tests := []struct {
+		name string
+		// change is applied to the input directory after the first split
+		change        func(t *testing.T, dir string)
+		edit          string // section file edited by hand before the re-split
+		wantRemaining []string
+		wantConflicts int
+	}{
+		{
+			name: "input no longer oversized",
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "guide.md"), "# Guide\n\nShort now.\n")
+			},
+		},
+		{
+			name: "input deleted",
+			change: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "guide.md")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "edited section is kept",
+			change: func(t *testing.T, dir string) {
+				writeFile(t, filepath.Join(dir, "guide.md"), "# Guide\n\nShort now.\n")
+			},
+			edit:          "install.md",
+			wantRemaining: []string{"install.md"},
+			wantConflicts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "guide.md"), long)
+			writeFile(t, filepath.Join(dir, "small.md"), "# Small\n")
+
+			if err := newBatchSplitter(t, nil).SplitAll([]string{dir}, ByHeaders); err != nil {
+				t.Fatal(err)
+			}
+			sectionDir := filepath.Join(dir, "guide")
+			if _, err := os.Stat(filepath.Join(sectionDir, "install.md")); err != nil {
+				t.Fatalf("first split did not write the sections: %v", err)
+			}
+
+			if tt.edit != "" {
+				writeFile(t, filepath.Join(sectionDir, tt.edit), "edited by hand\n")
+			}
+			tt.change(t, dir)
+
+			conflicts := 0
+			s := newBatchSplitter(t, func(e events.Event) {
+				if e.Kind == events.FileConflict {
+					conflicts++
+				}
+			})
+			if err := s.SplitAll([]string{dir}, ByHeaders); err != nil {
+				t.Fatal(err)
+			}
+
+			var remaining []string
+			entries, _ := os.ReadDir(sectionDir)
+			for _, entry := range entries {
+				remaining = append(remaining, entry.Name())
+			}
+			if strings.Join(remaining, ",") != strings.Join(tt.wantRemaining, ",") {
+				t.Errorf("remaining files = %v, want %v", remaining, tt.wantRemaining)
+			}
+			if len(tt.wantRemaining) == 0 {
+				if _, err := os.Stat(sectionDir); !os.IsNotExist(err) {
+					t.Errorf("section directory was not removed")
+				}
+			}
+			if conflicts != tt.wantConflicts {
+				t.Errorf("conflicts = %d, want %d", conflicts, tt.wantConflicts)
+			}
+
+			manifest, err := loadManifest(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(manifest.Sections) != 0 {
+				t.Errorf("manifest still lists %d sections", len(manifest.Sections))
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package splitter
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	DefaultFilenameTemplate  = "{prefix}{slug}.md"
+	NumberedFilenameTemplate = "{prefix}{nn}-{slug}.md"
+
+	maxSlugBytes = 80
+)
+
+// reservedFilenames are written alongside the sections by split itself.
+var reservedFilenames = map[string]bool{
+	IndexFile:    true,
+	ManifestFile: true,
+	ChunksFile:   true,
+}
+
+// assignFilenames names each section from FilenameTemplate, which may use
+// {prefix}, {slug}, {n} (1-based position) and {nn} (position zero-padded
+// to at least two digits). Names that collide with an earlier section or a
+// generated file get a numeric suffix.
+func (s *Splitter) assignFilenames(sections []Section) error {
+	template := s.FilenameTemplate
+	if template == "" {
+		template = DefaultFilenameTemplate
+	}
+	if !strings.Contains(template, "{slug}") && !strings.Contains(template, "{n}") && !strings.Contains(template, "{nn}") {
+		return fmt.Errorf("filename template %q must contain {slug}, {n} or {nn}", template)
+	}
+
+	width := max(len(strconv.Itoa(len(sections))), 2)
+	used := make(map[string]bool)
+
+	for i := range sections {
+		name := strings.NewReplacer(
+			"{prefix}", s.Prefix,
+			"{slug}", Slugify(sections[i].Title),
+			"{nn}", fmt.Sprintf("%0*d", width, i+1),
+			"{n}", strconv.Itoa(i+1),
+		).Replace(template)
+
+		if strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("filename %q must not contain path separators", name)
+		}
+
+		sections[i].Filename = uniqueFilename(name, used)
+	}
+
+	return nil
+}
+
+func uniqueFilename(name string, used map[string]bool) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	candidate := name
+	for n := 2; used[strings.ToLower(candidate)] || reservedFilenames[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s-%d%s", base, n, ext)
+	}
+
+	// Compare case-insensitively for case-insensitive filesystems
+	used[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// Slugify turns a heading into a filename-safe slug. Letters and digits of
+// any script are kept, so non-Latin headings keep their meaning; other
+// characters, including everything that needs escaping in paths or URLs,
+// are dropped or turned into dashes. An empty slug becomes "section".
+func Slugify(title string) string {
+	var slug strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+			if dash && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			dash = false
+			slug.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_' || r == '.' || r == '/':
+			dash = true
+		}
+	}
+
+	result := slug.String()
+	if len(result) > maxSlugBytes {
+		cut := maxSlugBytes
+		for cut > 0 && !utf8.RuneStart(result[cut]) {
+			cut--
+		}
+		result = strings.TrimRight(result[:cut], "-")
+	}
+
+	if result == "" {
+		return "section"
+	}
+	return result
+}
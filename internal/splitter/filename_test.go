@@ -0,0 +1,195 @@
+package splitter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title, want string
+	}{
+		{"Getting Started", "getting-started"},
+		{"API: /users/{id}", "api-users-id"},
+		{"  --Leading and trailing--  ", "leading-and-trailing"},
+		{"snake_case.and.dots", "snake-case-and-dots"},
+		{"インストール手順", "インストール手順"},
+		{"設定 と 使い方", "設定-と-使い方"},
+		{"Über Uns", "über-uns"},
+		{"Привет мир", "привет-мир"},
+		{"v1.2 Release", "v1-2-release"},
+		{"!!!", "section"},
+		{"", "section"},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.title); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestSlugifyTruncates(t *testing.T) {
+	tests := []struct {
+		name, title, want string
+	}{
+		{"at a rune boundary", strings.Repeat("日本", 30), strings.Repeat("日本", 13)},
+		{"without a trailing dash", strings.Repeat("a", 79) + " " + strings.Repeat("b", 20), strings.Repeat("a", 79)},
+		{"short titles are kept", strings.Repeat("a", maxSlugBytes), strings.Repeat("a", maxSlugBytes)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.title)
+			if got != tt.want {
+				t.Errorf("Slugify = %q, want %q", got, tt.want)
+			}
+			if len(got) > maxSlugBytes || !utf8.ValidString(got) {
+				t.Errorf("slug %q is %d bytes or not valid UTF-8", got, len(got))
+			}
+		})
+	}
+}
+
+func sectionsTitled(titles ...string) []Section {
+	sections := make([]Section, len(titles))
+	for i, title := range titles {
+		sections[i].Title = title
+	}
+	return sections
+}
+
+func TestAssignFilenames(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		prefix   string
+		titles   []string
+		want     []string
+	}{
+		{
+			name:   "default template",
+			titles: []string{"Install", "Usage"},
+			want:   []string{"install.md", "usage.md"},
+		},
+		{
+			name:     "numbered with prefix",
+			template: NumberedFilenameTemplate,
+			prefix:   "guide-",
+			titles:   []string{"Install", "Usage"},
+			want:     []string{"guide-01-install.md", "guide-02-usage.md"},
+		},
+		{
+			name:     "position only",
+			template: "part{n}.md",
+			titles:   []string{"A", "B"},
+			want:     []string{"part1.md", "part2.md"},
+		},
+		{
+			name:   "duplicate headings",
+			titles: []string{"Setup", "Setup", "setup", "SETUP"},
+			want:   []string{"setup.md", "setup-2.md", "setup-3.md", "setup-4.md"},
+		},
+		{
+			name:   "suffixed name taken by a later heading",
+			titles: []string{"Notes", "Notes", "Notes 2"},
+			want:   []string{"notes.md", "notes-2.md", "notes-2-2.md"},
+		},
+		{
+			name:   "reserved index.md",
+			titles: []string{"Index", "Overview"},
+			want:   []string{"index-2.md", "overview.md"},
+		},
+		{
+			name:     "reserved index.json",
+			template: "{slug}.json",
+			titles:   []string{"Index"},
+			want:     []string{"index-2.json"},
+		},
+		{
+			name:     "reserved chunks.jsonl",
+			template: "{slug}.jsonl",
+			titles:   []string{"Chunks"},
+			want:     []string{"chunks-2.jsonl"},
+		},
+		{
+			name:   "non-ASCII headings",
+			titles: []string{"概要", "インストール", "概要"},
+			want:   []string{"概要.md", "インストール.md", "概要-2.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Splitter{FilenameTemplate: tt.template, Prefix: tt.prefix}
+			sections := sectionsTitled(tt.titles...)
+			if err := s.assignFilenames(sections); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, section := range sections {
+				got = append(got, section.Filename)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("filenames = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignFilenamesPadsToSectionCount(t *testing.T) {
+	titles := make([]string, 120)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("Part %d", i+1)
+	}
+	sections := sectionsTitled(titles...)
+
+	s := &Splitter{FilenameTemplate: "{nn}.md"}
+	if err := s.assignFilenames(sections); err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{0, 8, 98, 99, 119} {
+		if want := fmt.Sprintf("%03d.md", i+1); sections[i].Filename != want {
+			t.Errorf("section %d is named %q, want %q", i+1, sections[i].Filename, want)
+		}
+	}
+}
+
+func TestAssignFilenamesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		prefix   string
+		want     string
+	}{
+		{"no placeholder", "{prefix}notes.md", "", "must contain {slug}, {n} or {nn}"},
+		{"separator in template", "{slug}/index.md", "", "must not contain path separators"},
+		{"separator in prefix", DefaultFilenameTemplate, "docs/", "must not contain path separators"},
+		{"backslash", `{n}\part.md`, "", "must not contain path separators"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Splitter{FilenameTemplate: tt.template, Prefix: tt.prefix}
+			err := s.assignFilenames(sectionsTitled("Install"))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniqueFilename(t *testing.T) {
+	used := map[string]bool{}
+	for _, tt := range []struct{ name, want string }{
+		{"guide.md", "guide.md"},
+		{"Guide.md", "Guide-2.md"},
+		{"guide-2.md", "guide-2-2.md"},
+		{"INDEX.md", "INDEX-2.md"},
+		{"README", "README"},
+		{"readme", "readme-2"},
+	} {
+		if got := uniqueFilename(tt.name, used); got != tt.want {
+			t.Errorf("uniqueFilename(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package splitter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+// IndexFile is the entry point generated for split output.
+const IndexFile = "index.md"
+
+const excerptLength = 160
+
+// writeIndex writes an index.md listing every section with a short excerpt
+// and token estimate. Sections nested under a common parent heading are
+// grouped beneath it.
+func (s *Splitter) writeIndex(source string, sections []Section, out *output.Writer) error {
+	var index strings.Builder
+
+	title := documentTitle(source, s.InputFile)
+	fmt.Fprintf(&index, "# %s\n\n", title)
+	noun := "sections"
+	if len(sections) == 1 {
+		noun = "section"
+	}
+	fmt.Fprintf(&index, "Split from `%s` into %d %s.\n", filepath.ToSlash(s.InputFile), len(sections), noun)
+
+	group := ""
+	for i, section := range sections {
+		// Group by parent headings, leaving out the document title itself
+		var parents []string
+		if len(section.HeadingPath) > 1 {
+			parents = section.HeadingPath[:len(section.HeadingPath)-1]
+			if parents[0] == title {
+				parents = parents[1:]
+			}
+		}
+		parent := strings.Join(parents, " › ")
+		if i == 0 || parent != group {
+			if parent != "" {
+				fmt.Fprintf(&index, "\n## %s\n", parent)
+			}
+			index.WriteString("\n")
+			group = parent
+		}
+
+		tokens := markdown.EstimateTokens(section.Content)
+		fmt.Fprintf(&index, "%d. [%s](%s) (~%d tokens)", i+1, section.Title, section.Filename, tokens)
+		if excerpt := excerpt(section.Content); excerpt != "" {
+			fmt.Fprintf(&index, "\n   %s", excerpt)
+		}
+		index.WriteString("\n")
+	}
+
+	filePath := filepath.Join(s.OutputDir, IndexFile)
+	action, err := out.WriteFile(filePath, []byte(index.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	s.emitWrite(filePath, action)
+	return nil
+}
+
+// documentTitle returns the first top-level heading of the source, or the
+// input filename when there is none.
+func documentTitle(source, inputFile string) string {
+	for _, block := range markdown.Blocks(source) {
+		if block.Kind == markdown.Heading && block.Level == 1 {
+			return block.Title
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+}
+
+// excerpt returns the start of the first paragraph or list of content,
+// collapsed to a single line.
+func excerpt(content string) string {
+	for _, block := range markdown.Blocks(content) {
+		if block.Kind != markdown.Paragraph && block.Kind != markdown.List {
+			continue
+		}
+
+		lines := strings.Split(block.Text, "\n")
+		for i, line := range lines {
+			lines[i] = markdown.ListItemPattern.ReplaceAllString(line, "")
+		}
+		text := strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+		if utf8.RuneCountInString(text) <= excerptLength {
+			return text
+		}
+
+		// Cut at the last space in the second half of the excerpt, counting runes
+		runes := []rune(text)[:excerptLength]
+		for i := len(runes) - 1; i > excerptLength/2; i-- {
+			if runes[i] == ' ' {
+				runes = runes[:i]
+				break
+			}
+		}
+		return string(runes) + "…"
+	}
+	return ""
+}
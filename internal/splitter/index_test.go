@@ -0,0 +1,101 @@
+package splitter
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+func TestWriteIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		sections []Section
+		want     string
+	}{
+		{
+			name:   "single section",
+			source: "# Guide\n",
+			sections: []Section{
+				{Title: "Install", Filename: "install.md", HeadingPath: []string{"Guide", "Install"}, Content: "## Install\n\nRun make.\n"},
+			},
+			want: "# Guide\n\nSplit from `guide.md` into 1 section.\n\n1. [Install](install.md) (~6 tokens)\n   Run make.\n",
+		},
+		{
+			name:   "grouped by parent heading",
+			source: "# Guide\n",
+			sections: []Section{
+				{Title: "Intro", Filename: "intro.md", HeadingPath: []string{"Guide", "Intro"}, Content: "### Intro\n"},
+				{Title: "Flags", Filename: "flags.md", HeadingPath: []string{"Guide", "Usage", "Flags"}, Content: "### Flags\n\n- `-v` verbose\n"},
+				{Title: "Env", Filename: "env.md", HeadingPath: []string{"Guide", "Usage", "Env"}, Content: "### Env\n"},
+			},
+			want: "# Guide\n\nSplit from `guide.md` into 3 sections.\n\n" +
+				"1. [Intro](intro.md) (~3 tokens)\n" +
+				"\n## Usage\n\n" +
+				"2. [Flags](flags.md) (~7 tokens)\n   `-v` verbose\n" +
+				"3. [Env](env.md) (~2 tokens)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := New("guide.md", dir, "")
+			out := &output.Writer{Force: true, Log: io.Discard}
+			if err := s.writeIndex(tt.source, tt.sections, out); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(dir, IndexFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("index =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentTitle(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"# Guide\n\n## Install\n", "Guide"},
+		{"intro\n\n## Install\n\n# Later Title\n", "Later Title"},
+		{"```\n# fenced\n```\n\n## Install\n", "notes"},
+	}
+	for _, tt := range tests {
+		if got := documentTitle(tt.source, filepath.Join("docs", "notes.md")); got != tt.want {
+			t.Errorf("documentTitle(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("word ", 50)
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"first paragraph", "## Title\n\nFirst line\nwraps here.\n\nSecond.\n", "First line wraps here."},
+		{"list markers dropped", "## Title\n\n- one\n- two\n1. three\n", "one two three"},
+		{"code is skipped", "## Title\n\n```\ncode\n```\n\nAfter code.\n", "After code."},
+		{"nothing to excerpt", "## Title\n\n```\ncode\n```\n", ""},
+		{"long text cut at a word", long, strings.TrimSpace(strings.Repeat("word ", 32)) + "…"},
+		{"non-ASCII text cut at a word", strings.Repeat("日本語 ", 50), strings.TrimSpace(strings.Repeat("日本語 ", 40)) + "…"},
+		{"early space in non-ASCII text is too short a cut", strings.Repeat("語", 30) + " " + strings.Repeat("文", 200), strings.Repeat("語", 30) + " " + strings.Repeat("文", 129) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := excerpt(tt.content); got != tt.want {
+				t.Errorf("excerpt = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
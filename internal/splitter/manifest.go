@@ -0,0 +1,209 @@
+package splitter
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+const (
+	ManifestFile = "index.json"
+	ChunksFile   = "chunks.jsonl"
+
+	manifestVersion = 1
+)
+
+// Manifest describes the sections written by a split, so that indexers and
+// embedding pipelines can consume the output without re-parsing it.
+type Manifest struct {
+	Version  int             `json:"version"`
+	Source   string          `json:"source"`
+	Method   string          `json:"method"`
+	Sections []ManifestEntry `json:"sections"`
+}
+
+type ManifestEntry struct {
+	// ID stays the same across re-splits as long as the section's source
+	// and heading path do not change.
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Filename    string   `json:"filename"`
+	Source      string   `json:"source"`
+	HeadingPath []string `json:"heading_path"`
+	StartByte   int      `json:"start_byte"`
+	EndByte     int      `json:"end_byte"`
+	StartLine   int      `json:"start_line"`
+	EndLine     int      `json:"end_line"`
+	Tokens      int      `json:"tokens"`
+	Hash        string   `json:"hash"`
+	// FileHash is the hash of the written file, navigation included; update
+	// mode compares it with the file on disk to detect local edits.
+	FileHash string `json:"file_hash,omitempty"`
+}
+
+// chunkRecord is one line of chunks.jsonl.
+type chunkRecord struct {
+	ManifestEntry
+	Content string `json:"content"`
+}
+
+func (m SplitMethod) String() string {
+	switch m {
+	case ByHeaders:
+		return "headers"
+	case ByLines:
+		return "lines"
+	case BySize:
+		return "size"
+	case BySemantic:
+		return "semantic"
+	default:
+		return fmt.Sprintf("method-%d", int(m))
+	}
+}
+
+// buildManifest describes sections split from the input file.
+func (s *Splitter) buildManifest(sections []Section, method SplitMethod) Manifest {
+	source := filepath.ToSlash(s.InputFile)
+	manifest := Manifest{
+		Version:  manifestVersion,
+		Source:   source,
+		Method:   method.String(),
+		Sections: make([]ManifestEntry, 0, len(sections)),
+	}
+
+	seen := make(map[string]int)
+	for i, section := range sections {
+		key := section.Title
+		if len(section.HeadingPath) > 0 {
+			key = strings.Join(section.HeadingPath, "\x00")
+		}
+		seen[key]++
+
+		manifest.Sections = append(manifest.Sections, ManifestEntry{
+			ID:          sectionID(source, key, seen[key]),
+			Title:       section.Title,
+			Filename:    s.manifestFilename(section.Filename),
+			Source:      source,
+			HeadingPath: section.HeadingPath,
+			StartByte:   section.StartByte,
+			EndByte:     section.EndByte,
+			StartLine:   section.StartLine,
+			EndLine:     section.EndLine,
+			Tokens:      markdown.EstimateTokens(section.Content),
+			Hash:        contentHash(section.Content),
+			FileHash:    contentHash(s.renderSection(sections, i)),
+		})
+	}
+
+	return manifest
+}
+
+func (s *Splitter) manifestDir() string {
+	if s.ManifestDir != "" {
+		return s.ManifestDir
+	}
+	return s.OutputDir
+}
+
+// manifestFilename returns the manifest's name for a section file, which
+// is relative to the manifest directory.
+func (s *Splitter) manifestFilename(filename string) string {
+	if s.ManifestDir == "" {
+		return filename
+	}
+	rel, err := filepath.Rel(s.ManifestDir, filepath.Join(s.OutputDir, filename))
+	if err != nil {
+		return filename
+	}
+	return filepath.ToSlash(rel)
+}
+
+// sectionFilename is the inverse of manifestFilename.
+func (s *Splitter) sectionFilename(name string) string {
+	if s.ManifestDir == "" {
+		return name
+	}
+	rel, err := filepath.Rel(s.OutputDir, filepath.Join(s.ManifestDir, filepath.FromSlash(name)))
+	if err != nil {
+		return name
+	}
+	return rel
+}
+
+// ownedOutput returns a writer that replaces files generated by the
+// splitter, such as the manifest, without Force.
+func (s *Splitter) ownedOutput() *output.Writer {
+	owned := *s.Output
+	owned.Force = true
+	return &owned
+}
+
+// sectionID derives a stable identifier from the section's source, heading
+// path or title, and its occurrence among sections with the same key.
+func sectionID(source, key string, occurrence int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d", source, key, occurrence)))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// writeManifest writes index.json and chunks.jsonl to the output directory.
+func (s *Splitter) writeManifest(manifest Manifest, sections []Section, out *output.Writer) error {
+	index, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	index = append(index, '\n')
+
+	var chunks bytes.Buffer
+	encoder := json.NewEncoder(&chunks)
+	encoder.SetEscapeHTML(false)
+	for i, entry := range manifest.Sections {
+		if err := encoder.Encode(chunkRecord{ManifestEntry: entry, Content: sections[i].Content}); err != nil {
+			return fmt.Errorf("failed to encode chunk %s: %w", entry.Filename, err)
+		}
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{ManifestFile, index},
+		{ChunksFile, chunks.Bytes()},
+	}
+
+	for _, file := range files {
+		filePath := filepath.Join(s.manifestDir(), file.name)
+		action, err := out.WriteFile(filePath, file.data, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		s.emitWrite(filePath, action)
+	}
+
+	return nil
+}
+
+// emitWrite reports the outcome of writing filePath.
+func (s *Splitter) emitWrite(filePath string, action output.Action) {
+	if s.Output.DryRun {
+		return
+	}
+	if action == output.Unchanged {
+		s.OnEvent.Emit(events.Event{Kind: events.FileUnchanged, Path: filePath})
+	} else {
+		s.OnEvent.Emit(events.Event{Kind: events.FileWritten, Path: filePath, Replaced: action == output.Overwrite})
+	}
+}
@@ -0,0 +1,116 @@
+package splitter
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	const source = "# Guide\n\n## Install\n\nRun make.\n\n## Usage\n\n### Flags\n\nUse -v.\n\n## Install\n\nAgain.\n"
+
+	tests := []struct {
+		name   string
+		method SplitMethod
+	}{
+		{"headers", ByHeaders},
+		{"lines", ByLines},
+		{"semantic", BySemantic},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "guide.md")
+			writeFile(t, input, source)
+
+			split := func() *Manifest {
+				s := New(input, filepath.Join(dir, "out"), "")
+				s.WriteManifest = true
+				s.LinesPerFile = 5
+				s.Output = &output.Writer{Force: true, Log: io.Discard}
+				if err := s.Split(tt.method); err != nil {
+					t.Fatal(err)
+				}
+				manifest, err := loadManifest(s.OutputDir)
+				if err != nil || manifest == nil {
+					t.Fatalf("manifest not written: %v", err)
+				}
+				return manifest
+			}
+			manifest := split()
+
+			if manifest.Version != manifestVersion || manifest.Method != tt.method.String() || manifest.Source != filepath.ToSlash(input) {
+				t.Errorf("manifest header = %d %s %s", manifest.Version, manifest.Method, manifest.Source)
+			}
+			if len(manifest.Sections) == 0 {
+				t.Fatal("manifest lists no sections")
+			}
+
+			records, err := loadChunks(filepath.Join(dir, "out"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != len(manifest.Sections) {
+				t.Fatalf("%d chunks for %d manifest entries", len(records), len(manifest.Sections))
+			}
+
+			ids := make(map[string]bool)
+			for i, entry := range manifest.Sections {
+				record := records[i]
+				if record.ID != entry.ID || record.Filename != entry.Filename {
+					t.Errorf("chunk %d is %s/%s, manifest says %s/%s", i, record.ID, record.Filename, entry.ID, entry.Filename)
+				}
+				if ids[entry.ID] {
+					t.Errorf("duplicate ID %s", entry.ID)
+				}
+				ids[entry.ID] = true
+
+				// Byte and line ranges point back into the source
+				if entry.StartByte < 0 || entry.EndByte > len(source) || entry.StartByte >= entry.EndByte {
+					t.Errorf("%s: byte range %d-%d", entry.Filename, entry.StartByte, entry.EndByte)
+				} else if lines := strings.Count(source[:entry.StartByte], "\n") + 1; lines != entry.StartLine {
+					t.Errorf("%s: starts on line %d, byte offset says %d", entry.Filename, entry.StartLine, lines)
+				}
+				if entry.Hash != contentHash(record.Content) {
+					t.Errorf("%s: hash does not match the chunk content", entry.Filename)
+				}
+
+				// The recorded file hash is that of the written file
+				written, err := os.ReadFile(filepath.Join(dir, "out", entry.Filename))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !generatedBy(entry, written) {
+					t.Errorf("%s: file hash does not match the written file", entry.Filename)
+				}
+			}
+
+			// IDs are stable across re-splits of the same source
+			again := split()
+			for i, entry := range again.Sections {
+				if entry.ID != manifest.Sections[i].ID {
+					t.Errorf("section %d changed ID from %s to %s", i, manifest.Sections[i].ID, entry.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestManifestFilenames(t *testing.T) {
+	s := &Splitter{OutputDir: filepath.Join("docs", "guide"), ManifestDir: "docs"}
+	if got := s.manifestFilename("install.md"); got != "guide/install.md" {
+		t.Errorf("manifestFilename = %q, want guide/install.md", got)
+	}
+	if got := s.sectionFilename("guide/install.md"); got != "install.md" {
+		t.Errorf("sectionFilename = %q, want install.md", got)
+	}
+
+	own := &Splitter{OutputDir: "docs"}
+	if got := own.manifestFilename("install.md"); got != "install.md" {
+		t.Errorf("manifestFilename without a shared manifest = %q", got)
+	}
+}
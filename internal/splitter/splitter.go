@@ -5,19 +5,40 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+	"github.com/claude-code/claude-doc-structure/internal/output"
 )
 
 type Splitter struct {
-	InputFile   string
-	OutputDir   string
-	Prefix      string
-	MaxSections int
-	HeaderLevel int
-	MaxSizeKB   int64
-	LinesPerFile int
+	InputFile     string
+	OutputDir     string
+	Prefix        string
+	MaxSections   int
+	HeaderLevel   int
+	MaxSizeKB     int64
+	LinesPerFile  int
 	AddNavigation bool
+	ChunkTokens   int
+	OverlapTokens int
+	WriteManifest bool
+	WriteIndex    bool
+	// Update rewrites only the sections that changed since the last split
+	// recorded in the manifest; see update.
+	Update bool
+	// ManifestDir is where the manifest is read and written, when it is
+	// shared with other inputs; it defaults to OutputDir.
+	ManifestDir string
+	// ThresholdTokens and ThresholdKB select the files SplitAll splits;
+	// ThresholdTokens takes precedence when set.
+	ThresholdTokens int
+	ThresholdKB     int64
+	// FilenameTemplate names section files; see assignFilenames.
+	FilenameTemplate string
+	Output           *output.Writer
+	OnEvent          events.Handler
 }
 
 type SplitMethod int
@@ -26,140 +47,212 @@ const (
 	ByHeaders SplitMethod = iota
 	ByLines
 	BySize
+	BySemantic
 )
 
 func New(inputFile, outputDir, prefix string) *Splitter {
 	return &Splitter{
-		InputFile:     inputFile,
-		OutputDir:     outputDir,
-		Prefix:        prefix,
-		MaxSections:   10,
-		HeaderLevel:   2,
-		MaxSizeKB:     100,
-		LinesPerFile:  200,
-		AddNavigation: true,
+		InputFile:        inputFile,
+		OutputDir:        outputDir,
+		Prefix:           prefix,
+		MaxSections:      10,
+		HeaderLevel:      2,
+		MaxSizeKB:        100,
+		LinesPerFile:     200,
+		AddNavigation:    true,
+		ChunkTokens:      500,
+		OverlapTokens:    50,
+		FilenameTemplate: DefaultFilenameTemplate,
+		ThresholdKB:      100,
+		Output:           output.New(),
 	}
 }
 
 func (s *Splitter) Split(method SplitMethod) error {
+	manifest, sections, err := s.split(method)
+	if err != nil {
+		return err
+	}
+
+	if s.WriteManifest || s.Update {
+		return s.writeManifest(manifest, sections, s.ownedOutput())
+	}
+	return nil
+}
+
+// split writes the sections of the input file and, if enabled, its index,
+// and returns the manifest describing them.
+func (s *Splitter) split(method SplitMethod) (Manifest, []Section, error) {
 	// Read input file
 	content, err := os.ReadFile(s.InputFile)
 	if err != nil {
-		return fmt.Errorf("failed to read input file: %w", err)
+		return Manifest{}, nil, fmt.Errorf("failed to read input file: %w", err)
 	}
 
 	// Create output directory
 	if s.OutputDir == "" {
 		s.OutputDir = filepath.Dir(s.InputFile)
 	}
-	
-	err = os.MkdirAll(s.OutputDir, 0755)
+
+	err = s.Output.MkdirAll(s.OutputDir)
+	if err != nil {
+		return Manifest{}, nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	sections, err := s.Sections(string(content), method)
 	if err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return Manifest{}, nil, err
 	}
 
+	if s.Update {
+		manifest, err := s.update(string(content), sections, method)
+		return manifest, sections, err
+	}
+
+	if err := s.writeSections(sections); err != nil {
+		return Manifest{}, nil, err
+	}
+
+	if s.WriteIndex {
+		if err := s.writeIndex(string(content), sections, s.Output); err != nil {
+			return Manifest{}, nil, err
+		}
+	}
+
+	return s.buildManifest(sections, method), sections, nil
+}
+
+// Sections splits content with the given method without writing anything.
+func (s *Splitter) Sections(content string, method SplitMethod) ([]Section, error) {
+	var sections []Section
 	switch method {
 	case ByHeaders:
-		return s.splitByHeaders(string(content))
+		sections = s.splitByHeaders(content)
 	case ByLines:
-		return s.splitByLines(string(content))
+		sections = s.splitByLines(content)
 	case BySize:
-		return s.splitBySize(string(content))
+		sections = s.splitBySize(content)
+	case BySemantic:
+		sections = s.splitBySemantic(content)
 	default:
-		return fmt.Errorf("unknown split method")
+		return nil, fmt.Errorf("unknown split method")
 	}
+
+	if err := s.assignFilenames(sections); err != nil {
+		return nil, err
+	}
+	return sections, nil
 }
 
-func (s *Splitter) splitByHeaders(content string) error {
+// splitByHeaders starts a section at each heading of HeaderLevel. Headings
+// inside fenced code blocks do not count, and text before the first
+// section heading is left out.
+func (s *Splitter) splitByHeaders(content string) []Section {
 	lines := strings.Split(content, "\n")
-	headerPattern := regexp.MustCompile(fmt.Sprintf(`^#{%d}\s+(.+)`, s.HeaderLevel))
-	
+	index := newLineIndex(lines)
+
 	var sections []Section
-	var currentSection Section
-	var currentLines []string
-	
-	for _, line := range lines {
-		if matches := headerPattern.FindStringSubmatch(line); matches != nil {
-			// Save previous section
-			if currentSection.Title != "" {
-				currentSection.Content = strings.Join(currentLines, "\n")
-				sections = append(sections, currentSection)
-			}
-			
-			// Start new section
-			currentSection = Section{
-				Title:    matches[1],
-				Filename: s.generateFilename(matches[1]),
-			}
-			currentLines = []string{line}
-		} else {
-			currentLines = append(currentLines, line)
+	var path markdown.HeadingPath
+	startLine := 0
+
+	// Each section runs up to the line before the next section heading
+	closeSection := func(endLine int) {
+		if len(sections) == 0 {
+			return
 		}
+		last := &sections[len(sections)-1]
+		index.setRange(last, startLine, endLine)
+		last.Content = strings.Join(lines[startLine:endLine+1], "\n")
 	}
-	
-	// Add last section
-	if currentSection.Title != "" {
-		currentSection.Content = strings.Join(currentLines, "\n")
-		sections = append(sections, currentSection)
+
+	for _, block := range markdown.Blocks(content) {
+		if block.Kind != markdown.Heading || block.Level > s.HeaderLevel {
+			continue
+		}
+		// Track enclosing headings for the heading path
+		path.Enter(block.Level, block.Title)
+		if block.Level != s.HeaderLevel || block.Title == "" {
+			continue
+		}
+
+		closeSection(block.StartLine - 2)
+		sections = append(sections, Section{
+			Title:       block.Title,
+			HeadingPath: path.Titles(),
+		})
+		startLine = block.StartLine - 1
 	}
-	
+	closeSection(len(lines) - 1)
+
 	// Limit sections if needed
 	if len(sections) > s.MaxSections {
 		sections = sections[:s.MaxSections]
 	}
-	
-	return s.writeSections(sections)
+
+	return sections
 }
 
-func (s *Splitter) splitByLines(content string) error {
+func (s *Splitter) splitByLines(content string) []Section {
 	lines := strings.Split(content, "\n")
+	index := newLineIndex(lines)
 	var sections []Section
-	
-	for i := 0; i < len(lines); i += s.LinesPerFile {
+
+	// The empty string after a final newline is not a line of its own
+	count := len(lines)
+	if count > 1 && lines[count-1] == "" {
+		count--
+	}
+
+	for i := 0; i < count; i += s.LinesPerFile {
 		end := i + s.LinesPerFile
-		if end > len(lines) {
+		if end >= count {
 			end = len(lines)
 		}
-		
+
 		sectionLines := lines[i:end]
 		title := fmt.Sprintf("Part %d", (i/s.LinesPerFile)+1)
-		
+
 		section := Section{
-			Title:    title,
-			Filename: s.generateFilename(title),
-			Content:  strings.Join(sectionLines, "\n"),
+			Title:   title,
+			Content: strings.Join(sectionLines, "\n"),
 		}
-		
+		index.setRange(&section, i, end-1)
+
 		sections = append(sections, section)
 	}
-	
-	return s.writeSections(sections)
+
+	return sections
 }
 
-func (s *Splitter) splitBySize(content string) error {
+func (s *Splitter) splitBySize(content string) []Section {
 	maxSizeBytes := s.MaxSizeKB * 1024
+	index := newLineIndex(strings.Split(content, "\n"))
 	var sections []Section
-	
+
 	currentSize := int64(0)
 	var currentLines []string
 	partNum := 1
-	
+	lineNum := -1
+	startLine := 0
+
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	for scanner.Scan() {
+		lineNum++
 		line := scanner.Text()
 		lineSize := int64(len(line) + 1) // +1 for newline
-		
+
 		if currentSize+lineSize > maxSizeBytes && len(currentLines) > 0 {
 			// Create section
 			title := fmt.Sprintf("Part %d", partNum)
 			section := Section{
-				Title:    title,
-				Filename: s.generateFilename(title),
-				Content:  strings.Join(currentLines, "\n"),
+				Title:   title,
+				Content: strings.Join(currentLines, "\n"),
 			}
+			index.setRange(&section, startLine, lineNum-1)
 			sections = append(sections, section)
-			
+
 			// Reset for next section
+			startLine = lineNum
 			currentLines = []string{line}
 			currentSize = lineSize
 			partNum++
@@ -168,80 +261,207 @@ func (s *Splitter) splitBySize(content string) error {
 			currentSize += lineSize
 		}
 	}
-	
+
 	// Add last section
 	if len(currentLines) > 0 {
 		title := fmt.Sprintf("Part %d", partNum)
 		section := Section{
-			Title:    title,
-			Filename: s.generateFilename(title),
-			Content:  strings.Join(currentLines, "\n"),
+			Title:   title,
+			Content: strings.Join(currentLines, "\n"),
 		}
+		index.setRange(&section, startLine, lineNum)
 		sections = append(sections, section)
 	}
-	
-	return s.writeSections(sections)
+
+	return sections
 }
 
-func (s *Splitter) generateFilename(title string) string {
-	// Clean title for filename
-	cleaned := strings.ToLower(title)
-	cleaned = regexp.MustCompile(`[^a-z0-9\s-]`).ReplaceAllString(cleaned, "")
-	cleaned = regexp.MustCompile(`\s+`).ReplaceAllString(cleaned, "-")
-	cleaned = strings.Trim(cleaned, "-")
-	
-	if s.Prefix != "" {
-		return fmt.Sprintf("%s%s.md", s.Prefix, cleaned)
-	}
-	return fmt.Sprintf("%s.md", cleaned)
+// splitBySemantic chunks content on block boundaries toward ChunkTokens.
+// Fenced code, tables and lists are never split, so a single oversized
+// block becomes a chunk of its own, and a heading always stays in the same
+// chunk as the block that follows it. Each chunk repeats up to OverlapTokens
+// of trailing blocks from the previous chunk and is prefixed with the
+// heading breadcrumb it starts under, so chunks stand alone in a
+// retrieval index.
+func (s *Splitter) splitBySemantic(content string) []Section {
+	blocks := markdown.Blocks(content)
+	var sections []Section
+
+	var path markdown.HeadingPath
+	var chunk []markdown.Block
+	var chunkPath []string
+	chunkTokens := 0
+	// overlap leading blocks, worth carriedTokens, are repeated from the previous chunk
+	overlap := 0
+	carriedTokens := 0
+
+	flush := func() {
+		if len(chunk) == overlap {
+			return
+		}
+
+		title := fmt.Sprintf("Chunk %d", len(sections)+1)
+		body := content[chunk[0].Start:chunk[len(chunk)-1].End]
+		if len(chunkPath) > 0 {
+			body = fmt.Sprintf("> Section: %s\n\n%s", strings.Join(chunkPath, " > "), body)
+		}
+
+		sections = append(sections, Section{
+			Title:       title,
+			Content:     strings.TrimRight(body, "\n") + "\n",
+			HeadingPath: chunkPath,
+			StartByte:   chunk[0].Start,
+			EndByte:     chunk[len(chunk)-1].End,
+			StartLine:   chunk[0].StartLine,
+			EndLine:     chunk[len(chunk)-1].EndLine,
+		})
+
+		// Carry trailing blocks over as overlap, never a heading on its own
+		overlap = 0
+		tokens := 0
+		for i := len(chunk) - 1; i > 0; i-- {
+			blockTokens := markdown.EstimateTokens(chunk[i].Text)
+			if tokens+blockTokens > s.OverlapTokens || chunk[i].Kind == markdown.Heading {
+				break
+			}
+			tokens += blockTokens
+			overlap++
+		}
+		chunk = append([]markdown.Block(nil), chunk[len(chunk)-overlap:]...)
+		chunkTokens = tokens
+		carriedTokens = tokens
+	}
+
+	for i, block := range blocks {
+		blockTokens := markdown.EstimateTokens(block.Text)
+		newContent := len(chunk) - overlap
+
+		// A heading only fits together with the headings and first block below it
+		unitTokens := blockTokens
+		for j := i + 1; block.Kind == markdown.Heading && j < len(blocks); j++ {
+			unitTokens += markdown.EstimateTokens(blocks[j].Text)
+			if blocks[j].Kind != markdown.Heading {
+				break
+			}
+		}
+		headingsOnly := true
+		for _, b := range chunk[overlap:] {
+			if b.Kind != markdown.Heading {
+				headingsOnly = false
+				break
+			}
+		}
+
+		// Prefer to start chunks at headings once a chunk is half full
+		startsSection := block.Kind == markdown.Heading && chunkTokens-carriedTokens >= s.ChunkTokens/2
+		if newContent > 0 && !headingsOnly && (chunkTokens+unitTokens > s.ChunkTokens || startsSection) {
+			flush()
+		}
+
+		if block.Kind == markdown.Heading {
+			path.Enter(block.Level, block.Title)
+		}
+		if len(chunk) == overlap {
+			chunkPath = path.Titles()
+		}
+
+		chunk = append(chunk, block)
+		chunkTokens += blockTokens
+	}
+	flush()
+
+	return sections
 }
 
 func (s *Splitter) writeSections(sections []Section) error {
 	for i, section := range sections {
-		content := section.Content
-		
-		// Add navigation if enabled
-		if s.AddNavigation {
-			content = s.addNavigation(content, sections, i)
-		}
-		
+		content := s.renderSection(sections, i)
+
 		filePath := filepath.Join(s.OutputDir, section.Filename)
-		err := os.WriteFile(filePath, []byte(content), 0644)
+		action, err := s.Output.WriteFile(filePath, []byte(content), 0644)
 		if err != nil {
 			return fmt.Errorf("failed to write section %s: %w", section.Filename, err)
 		}
-		
-		fmt.Printf("Created: %s\n", filePath)
+
+		s.emitWrite(filePath, action)
 	}
-	
+
 	return nil
 }
 
+// renderSection returns the file content for sections[i].
+func (s *Splitter) renderSection(sections []Section, i int) string {
+	// Add navigation if enabled
+	if s.AddNavigation {
+		return s.addNavigation(sections[i].Content, sections, i)
+	}
+	return sections[i].Content
+}
+
 func (s *Splitter) addNavigation(content string, sections []Section, currentIndex int) string {
 	var nav strings.Builder
-	
+
 	nav.WriteString("---\n")
 	nav.WriteString("## Navigation\n\n")
-	
+
+	// Breadcrumb for sections nested under other headings
+	if s.WriteIndex && len(sections[currentIndex].HeadingPath) > 1 {
+		crumbs := append([]string{fmt.Sprintf("[Index](%s)", IndexFile)}, sections[currentIndex].HeadingPath...)
+		nav.WriteString(fmt.Sprintf("%s\n\n", strings.Join(crumbs, " › ")))
+	}
+
+	// Up
+	if s.WriteIndex {
+		nav.WriteString(fmt.Sprintf("↑ [Index](%s)\n", IndexFile))
+	}
+
 	// Previous
 	if currentIndex > 0 {
-		nav.WriteString(fmt.Sprintf("← [Previous: %s](%s)\n", 
+		nav.WriteString(fmt.Sprintf("← [Previous: %s](%s)\n",
 			sections[currentIndex-1].Title, sections[currentIndex-1].Filename))
 	}
-	
+
 	// Next
 	if currentIndex < len(sections)-1 {
-		nav.WriteString(fmt.Sprintf("→ [Next: %s](%s)\n", 
+		nav.WriteString(fmt.Sprintf("→ [Next: %s](%s)\n",
 			sections[currentIndex+1].Title, sections[currentIndex+1].Filename))
 	}
-	
+
 	nav.WriteString("\n---\n\n")
-	
+
 	return nav.String() + content
 }
 
 type Section struct {
-	Title    string
-	Filename string
-	Content  string
-}
\ No newline at end of file
+	Title       string
+	Filename    string
+	Content     string
+	HeadingPath []string
+	// StartByte and EndByte locate the section in the source, end exclusive;
+	// StartLine and EndLine are 1-based and inclusive.
+	StartByte int
+	EndByte   int
+	StartLine int
+	EndLine   int
+}
+
+// lineIndex holds the byte offset at which each source line starts.
+type lineIndex []int
+
+func newLineIndex(lines []string) lineIndex {
+	index := make(lineIndex, len(lines)+1)
+	for i, line := range lines {
+		index[i+1] = index[i] + len(line) + 1
+	}
+	// The last line has no trailing newline
+	index[len(lines)]--
+	return index
+}
+
+// setRange records that section spans the 0-based lines from through to.
+func (index lineIndex) setRange(section *Section, from, to int) {
+	section.StartByte = index[from]
+	section.EndByte = index[to+1]
+	section.StartLine = from + 1
+	section.EndLine = to + 1
+}
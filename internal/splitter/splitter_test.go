@@ -0,0 +1,218 @@
+package splitter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+func TestSplitByHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		headerLevel int
+		wantTitles  []string
+		wantPaths   []string
+	}{
+		{
+			name:        "level two",
+			content:     "# Doc\n\nintro\n\n## One\n\nfirst\n\n### Detail\n\n## Two\n\nsecond\n",
+			headerLevel: 2,
+			wantTitles:  []string{"One", "Two"},
+			wantPaths:   []string{"Doc > One", "Doc > Two"},
+		},
+		{
+			name:        "fenced headings are code",
+			content:     "# Doc\n\n## Setup\n\n```sh\n## not a heading\n# comment\n```\n\n~~~\n## also code\n~~~\n\n## Usage\n\ntext\n",
+			headerLevel: 2,
+			wantTitles:  []string{"Setup", "Usage"},
+			wantPaths:   []string{"Doc > Setup", "Doc > Usage"},
+		},
+		{
+			name:        "fenced top-level heading leaves the path alone",
+			content:     "# Doc\n\n## A\n\n```markdown\n# Example Project\n```\n\n## B\n",
+			headerLevel: 2,
+			wantTitles:  []string{"A", "B"},
+			wantPaths:   []string{"Doc > A", "Doc > B"},
+		},
+		{
+			name:        "level three",
+			content:     "# Doc\n\n## Group\n\n### X\n\nx\n\n### Y\n\ny\n",
+			headerLevel: 3,
+			wantTitles:  []string{"X", "Y"},
+			wantPaths:   []string{"Doc > Group > X", "Doc > Group > Y"},
+		},
+		{
+			name:        "no section headings",
+			content:     "# Doc\n\nonly text\n",
+			headerLevel: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New("doc.md", "", "")
+			s.HeaderLevel = tt.headerLevel
+			sections := s.splitByHeaders(tt.content)
+
+			var titles, paths []string
+			for _, section := range sections {
+				titles = append(titles, section.Title)
+				paths = append(paths, strings.Join(section.HeadingPath, " > "))
+
+				// The recorded range is the section's source and the line
+				// break before the next section
+				if got := tt.content[section.StartByte:section.EndByte]; got != section.Content && got != section.Content+"\n" {
+					t.Errorf("%s: source range %q does not match content %q", section.Title, got, section.Content)
+				}
+				if !strings.HasPrefix(section.Content, strings.Repeat("#", tt.headerLevel)+" "+section.Title) {
+					t.Errorf("%s: content does not start with its heading: %q", section.Title, section.Content)
+				}
+			}
+			if strings.Join(titles, ",") != strings.Join(tt.wantTitles, ",") {
+				t.Errorf("titles = %v, want %v", titles, tt.wantTitles)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Errorf("heading paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestSplitByHeadersKeepsFencesWhole(t *testing.T) {
+	content := "## Setup\n\n```sh\n## build\nmake\n```\n\n## Usage\n\nrun it\n"
+	sections := New("doc.md", "", "").splitByHeaders(content)
+
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, want 2", len(sections))
+	}
+	if !strings.Contains(sections[0].Content, "```sh\n## build\nmake\n```") {
+		t.Errorf("fence was split: %q", sections[0].Content)
+	}
+	if sections[1].StartLine != 8 || sections[1].EndLine != 11 {
+		t.Errorf("Usage spans lines %d-%d, want 8-11", sections[1].StartLine, sections[1].EndLine)
+	}
+}
+
+func TestSplitBySemantic(t *testing.T) {
+	content := "# Guide\n\nIntro paragraph about the guide.\n\n" +
+		"## Setup\n\n```sh\n" + strings.Repeat("make build\n", 30) + "```\n\n" +
+		"Run the tests after building.\n\nThen install it.\n\n" +
+		"## Data\n\n| key | value |\n|-----|-------|\n| a   | 1     |\n| b   | 2     |\n\n" +
+		"Short note.\n\n- one\n- two\n  continued\n- three\n\n" +
+		"### Details\n\nClosing words for the details.\n\nA final remark.\n"
+
+	s := New("doc.md", "", "")
+	s.ChunkTokens = 30
+	s.OverlapTokens = 8
+	chunks := s.splitBySemantic(content)
+	if len(chunks) < 3 {
+		t.Fatalf("got %d chunks, want at least 3", len(chunks))
+	}
+
+	blocks := markdown.Blocks(content)
+	// blocksIn returns the blocks between two byte offsets
+	blocksIn := func(start, end int) []markdown.Block {
+		var in []markdown.Block
+		for _, block := range blocks {
+			if block.Start >= start && block.End <= end {
+				in = append(in, block)
+			}
+		}
+		return in
+	}
+
+	var path markdown.HeadingPath
+	next := 0
+	overlaps := 0
+	for i, chunk := range chunks {
+		in := blocksIn(chunk.StartByte, chunk.EndByte)
+
+		// Chunks start and end on block boundaries, so fences, tables and
+		// lists are never split
+		if len(in) == 0 || in[0].Start != chunk.StartByte || in[len(in)-1].End != chunk.EndByte {
+			t.Errorf("chunk %d (%d-%d) does not cover whole blocks", i+1, chunk.StartByte, chunk.EndByte)
+			continue
+		}
+
+		// A heading is never left without the block below it
+		if last := in[len(in)-1]; last.Kind == markdown.Heading {
+			t.Errorf("chunk %d ends with heading %q", i+1, last.Title)
+		}
+
+		// Trailing blocks of the previous chunk are repeated, up to
+		// OverlapTokens and without headings
+		fresh := in
+		if i > 0 && chunk.StartByte < chunks[i-1].EndByte {
+			overlaps++
+			carried := blocksIn(chunk.StartByte, chunks[i-1].EndByte)
+			tokens := 0
+			for _, block := range carried {
+				tokens += markdown.EstimateTokens(block.Text)
+				if block.Kind == markdown.Heading {
+					t.Errorf("chunk %d repeats heading %q", i+1, block.Title)
+				}
+			}
+			if tokens > s.OverlapTokens {
+				t.Errorf("chunk %d repeats %d tokens, want at most %d", i+1, tokens, s.OverlapTokens)
+			}
+			fresh = in[len(carried):]
+		}
+
+		// The breadcrumb names the headings the new content starts under
+		for ; next < len(blocks) && blocks[next].Start <= fresh[0].Start; next++ {
+			if blocks[next].Kind == markdown.Heading {
+				path.Enter(blocks[next].Level, blocks[next].Title)
+			}
+		}
+		breadcrumb := "> Section: " + strings.Join(path.Titles(), " > ") + "\n\n"
+		if !strings.HasPrefix(chunk.Content, breadcrumb) {
+			t.Errorf("chunk %d does not start with %q: %q", i+1, breadcrumb, chunk.Content)
+		}
+		if body := strings.TrimPrefix(chunk.Content, breadcrumb); body != strings.TrimRight(content[chunk.StartByte:chunk.EndByte], "\n")+"\n" {
+			t.Errorf("chunk %d body = %q", i+1, body)
+		}
+
+		// New content stays within ChunkTokens unless it is a single
+		// oversized block with the headings above it
+		tokens, content := 0, 0
+		for _, block := range fresh {
+			tokens += markdown.EstimateTokens(block.Text)
+			if block.Kind != markdown.Heading {
+				content++
+			}
+		}
+		if tokens > s.ChunkTokens && content > 1 {
+			t.Errorf("chunk %d holds %d new tokens in %d blocks, want at most %d", i+1, tokens, content, s.ChunkTokens)
+		}
+	}
+	if overlaps == 0 {
+		t.Error("no chunk repeats the end of the previous chunk")
+	}
+
+	// The oversized fence is a chunk of its own, kept whole
+	fence := "```sh\n" + strings.Repeat("make build\n", 30) + "```\n"
+	found := false
+	for _, chunk := range chunks {
+		if strings.Contains(chunk.Content, fence) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("no chunk contains the whole fence")
+	}
+}
+
+func TestSplitBySemanticKeepsHeadingWithOversizedBlock(t *testing.T) {
+	content := "# Doc\n\nIntro.\n\n## Big\n\n" + "```\n" + strings.Repeat("x\n", 200) + "```\n"
+	s := New("doc.md", "", "")
+	s.ChunkTokens = 20
+	chunks := s.splitBySemantic(content)
+
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	if strings.Contains(chunks[0].Content, "## Big") || !strings.Contains(chunks[1].Content, "## Big\n\n```") {
+		t.Errorf("heading was separated from its fence: %q, %q", chunks[0].Content, chunks[1].Content)
+	}
+}
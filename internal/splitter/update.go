@@ -0,0 +1,225 @@
+package splitter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+)
+
+// loadManifest reads the manifest written to dir by an earlier split. It
+// returns nil without an error when there is none.
+func loadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, ManifestFile), err)
+	}
+	return &manifest, nil
+}
+
+// loadChunks reads the chunks.jsonl written to dir by an earlier split.
+func loadChunks(dir string) ([]chunkRecord, error) {
+	file, err := os.Open(filepath.Join(dir, ChunksFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chunks: %w", err)
+	}
+	defer file.Close()
+
+	var records []chunkRecord
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		var record chunkRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, ChunksFile), err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// update re-splits into an output directory that already holds a split of
+// the same input. Sections are matched to the files recorded in the
+// manifest by ID, then by title, and keep their filenames. Files whose
+// content is unchanged are left alone, files that no section maps to any
+// more are removed, and files edited since they were generated are kept
+// unless Force is set: as conflicts when their generated content changed,
+// and otherwise as kept edits. The returned
+// manifest must be written, since the next update depends on it.
+func (s *Splitter) update(content string, sections []Section, method SplitMethod) (Manifest, error) {
+	previous, err := loadManifest(s.manifestDir())
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	source := filepath.ToSlash(s.InputFile)
+	var recorded []ManifestEntry
+	if previous != nil {
+		for _, entry := range previous.Sections {
+			if entry.Source == source {
+				entry.Filename = s.sectionFilename(entry.Filename)
+				recorded = append(recorded, entry)
+			}
+		}
+	}
+
+	matches := matchSections(s.buildManifest(sections, method).Sections, recorded)
+	keepFilenames(sections, matches, recorded)
+	manifest := s.buildManifest(sections, method)
+
+	byFilename := make(map[string]ManifestEntry, len(recorded))
+	for _, entry := range recorded {
+		byFilename[strings.ToLower(entry.Filename)] = entry
+	}
+
+	// Files recorded in the manifest are ours to replace
+	owned := s.ownedOutput()
+
+	written := make(map[string]bool, len(sections))
+	for i, section := range sections {
+		written[strings.ToLower(section.Filename)] = true
+		filePath := filepath.Join(s.OutputDir, section.Filename)
+		data := s.renderSection(sections, i)
+
+		existing, err := os.ReadFile(filePath)
+		if err != nil && !os.IsNotExist(err) {
+			return Manifest{}, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+
+		if err == nil && string(existing) != data && !s.Output.Force {
+			entry, ok := byFilename[strings.ToLower(section.Filename)]
+			if !ok || !generatedBy(entry, existing) {
+				// A local edit only conflicts when the generated file changed too
+				if !ok || entry.FileHash != manifest.Sections[i].FileHash {
+					s.OnEvent.Emit(events.Event{Kind: events.FileConflict, Path: filePath})
+				} else {
+					s.OnEvent.Emit(events.Event{Kind: events.FileKept, Path: filePath})
+				}
+				// Keep the recorded hash so the edit is still detected next time
+				manifest.Sections[i].FileHash = entry.FileHash
+				continue
+			}
+		}
+
+		action, err := owned.WriteFile(filePath, []byte(data), 0644)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to write section %s: %w", section.Filename, err)
+		}
+		s.emitWrite(filePath, action)
+	}
+
+	// Remove the files of sections that no longer exist
+	for _, entry := range recorded {
+		if written[strings.ToLower(entry.Filename)] {
+			continue
+		}
+
+		filePath := filepath.Join(s.OutputDir, entry.Filename)
+		existing, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+
+		if !generatedBy(entry, existing) && !s.Output.Force {
+			s.OnEvent.Emit(events.Event{Kind: events.FileConflict, Path: filePath})
+			continue
+		}
+
+		if err := owned.Remove(filePath); err != nil {
+			return Manifest{}, err
+		}
+		if !owned.DryRun {
+			s.OnEvent.Emit(events.Event{Kind: events.FileRemoved, Path: filePath})
+		}
+	}
+
+	if s.WriteIndex {
+		if err := s.writeIndex(content, sections, owned); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	return manifest, nil
+}
+
+// generatedBy reports whether content is still the file recorded by entry.
+// Entries without a file hash cannot be verified and count as edited.
+func generatedBy(entry ManifestEntry, content []byte) bool {
+	return entry.FileHash != "" && entry.FileHash == contentHash(string(content))
+}
+
+// matchSections pairs each current entry with the index of a recorded
+// entry with the same ID, falling back to an unclaimed entry with the same
+// title, or -1.
+func matchSections(current, recorded []ManifestEntry) []int {
+	byID := make(map[string]int, len(recorded))
+	byTitle := make(map[string][]int)
+	for j, entry := range recorded {
+		byID[entry.ID] = j
+		byTitle[entry.Title] = append(byTitle[entry.Title], j)
+	}
+
+	matches := make([]int, len(current))
+	claimed := make([]bool, len(recorded))
+	for i, entry := range current {
+		matches[i] = -1
+		if j, ok := byID[entry.ID]; ok && !claimed[j] {
+			matches[i] = j
+			claimed[j] = true
+		}
+	}
+
+	for i, entry := range current {
+		if matches[i] >= 0 {
+			continue
+		}
+		for _, j := range byTitle[entry.Title] {
+			if !claimed[j] {
+				matches[i] = j
+				claimed[j] = true
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// keepFilenames gives matched sections the filename they were written to
+// before, so that links into the split keep working, as long as no other
+// section now uses that name.
+func keepFilenames(sections []Section, matches []int, recorded []ManifestEntry) {
+	taken := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		taken[strings.ToLower(section.Filename)] = true
+	}
+
+	for i, j := range matches {
+		if j < 0 || recorded[j].Filename == sections[i].Filename {
+			continue
+		}
+		name := recorded[j].Filename
+		if taken[strings.ToLower(name)] || strings.ContainsAny(name, `/\`) {
+			continue
+		}
+		delete(taken, strings.ToLower(sections[i].Filename))
+		taken[strings.ToLower(name)] = true
+		sections[i].Filename = name
+	}
+}
@@ -0,0 +1,147 @@
+package splitter
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+)
+
+func TestUpdate(t *testing.T) {
+	const source = "# Doc\n\n## A\n\na\n\n## B\n\nb\n"
+
+	tests := []struct {
+		name   string
+		edit   string // section file edited by hand, if any
+		source string // source after the first split
+		force  bool
+		// want maps each section file to the event it gets, with
+		// "updated" for a FileWritten event replacing a file
+		want map[string]string
+	}{
+		{
+			name:   "unchanged source",
+			source: source,
+			want:   map[string]string{"a.md": "unchanged", "b.md": "unchanged"},
+		},
+		{
+			name:   "changed section",
+			source: strings.Replace(source, "\nb\n", "\nb2\n", 1),
+			want:   map[string]string{"a.md": "unchanged", "b.md": "updated"},
+		},
+		{
+			name:   "edited section that changed in the source",
+			edit:   "a.md",
+			source: strings.Replace(source, "\na\n", "\na2\n", 1),
+			want:   map[string]string{"a.md": "conflict", "b.md": "unchanged"},
+		},
+		{
+			name:   "edited section that did not change",
+			edit:   "a.md",
+			source: strings.Replace(source, "\nb\n", "\nb2\n", 1),
+			want:   map[string]string{"a.md": "kept", "b.md": "updated"},
+		},
+		{
+			name:   "edited section with an unchanged source",
+			edit:   "b.md",
+			source: source,
+			want:   map[string]string{"a.md": "unchanged", "b.md": "kept"},
+		},
+		{
+			name:   "forced over an edit",
+			edit:   "a.md",
+			source: strings.Replace(source, "\na\n", "\na2\n", 1),
+			force:  true,
+			want:   map[string]string{"a.md": "updated", "b.md": "unchanged"},
+		},
+		{
+			name:   "removed section",
+			source: "# Doc\n\n## A\n\na\n",
+			// a.md loses its navigation link to b.md
+			want: map[string]string{"a.md": "updated", "b.md": "removed"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			input := filepath.Join(dir, "doc.md")
+			outputDir := filepath.Join(dir, "out")
+			writeFile(t, input, source)
+
+			split := func(force bool, onEvent events.Handler) {
+				s := New(input, outputDir, "")
+				s.Update = true
+				s.Output = &output.Writer{Force: force, Log: io.Discard}
+				s.OnEvent = onEvent
+				if err := s.Split(ByHeaders); err != nil {
+					t.Fatal(err)
+				}
+			}
+			split(false, nil)
+
+			if tt.edit != "" {
+				path := filepath.Join(outputDir, tt.edit)
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				writeFile(t, path, string(data)+"\nlocal note\n")
+			}
+			writeFile(t, input, tt.source)
+
+			got := make(map[string]string)
+			split(tt.force, func(e events.Event) {
+				name := filepath.Base(e.Path)
+				if filepath.Ext(name) != ".md" {
+					return
+				}
+				switch {
+				case e.Kind == events.FileWritten && e.Replaced:
+					got[name] = "updated"
+				case e.Kind == events.FileWritten:
+					got[name] = "created"
+				case e.Kind == events.FileUnchanged:
+					got[name] = "unchanged"
+				case e.Kind == events.FileConflict:
+					got[name] = "conflict"
+				case e.Kind == events.FileKept:
+					got[name] = "kept"
+				case e.Kind == events.FileRemoved:
+					got[name] = "removed"
+				}
+			})
+
+			if describe(got) != describe(tt.want) {
+				t.Errorf("events = %s, want %s", describe(got), describe(tt.want))
+			}
+			if tt.edit != "" && !tt.force {
+				data, _ := os.ReadFile(filepath.Join(outputDir, tt.edit))
+				if !strings.Contains(string(data), "local note") {
+					t.Errorf("the local edit of %s was overwritten", tt.edit)
+				}
+			}
+		})
+	}
+}
+
+func describe(events map[string]string) string {
+	var parts []string
+	for name, event := range events {
+		parts = append(parts, name+": "+event)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
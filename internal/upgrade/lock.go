@@ -0,0 +1,76 @@
+package upgrade
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/claude-code/claude-doc-structure/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// LockFile records, in the project root, the templates init generated the
+// documentation files from.
+const LockFile = ".claude-docs.lock"
+
+const lockHeader = "# Generated by claude-docs. Records the templates the documentation was\n# created from so that claude-docs upgrade can merge template changes.\n"
+
+// Lock is the content of LockFile.
+type Lock struct {
+	// Version is the template version the files were last generated from.
+	Version int `yaml:"version"`
+	// Answers are the answers the templates were filled with.
+	Answers project.Answers `yaml:"answers,omitempty"`
+	// Files maps each generated file to the content init wrote, the base
+	// of the next upgrade.
+	Files map[string]string `yaml:"files,omitempty"`
+}
+
+// LoadLock reads LockFile from dir. It returns nil when the project has no
+// lock file, such as projects initialized by earlier versions.
+func LoadLock(dir string) (*Lock, error) {
+	path := filepath.Join(dir, LockFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if lock.Files == nil {
+		lock.Files = make(map[string]string)
+	}
+	return &lock, nil
+}
+
+// FileNames returns the recorded files in order.
+func (l *Lock) FileNames() []string {
+	var names []string
+	for name := range l.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Marshal encodes the lock as YAML.
+func (l *Lock) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(lockHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(l); err != nil {
+		return nil, fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,271 @@
+// Package upgrade brings files generated from earlier templates up to date
+// with a three-way merge of their sections: the template the file was
+// generated from, the file as the user edited it, and the current template.
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/claude-code/claude-doc-structure/internal/markdown"
+)
+
+// Conflict markers surround the user's and the template's version of a
+// section that both changed.
+const (
+	MarkerYours    = "<<<<<<< yours"
+	MarkerSplit    = "======="
+	MarkerTemplate = ">>>>>>> template"
+)
+
+// HasConflictMarkers reports whether content still holds conflict markers
+// from an earlier merge.
+func HasConflictMarkers(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == MarkerYours || line == MarkerTemplate {
+			return true
+		}
+	}
+	return false
+}
+
+// Section is a heading and the text up to the next heading of any level.
+// The text before the first heading is a section with an empty path.
+type Section struct {
+	// Path holds the titles of the heading and the headings enclosing it.
+	Path []string
+	// Key identifies the section, numbering repeated paths. The document
+	// title does not take part, since it is usually the project name.
+	Key  string
+	Text string
+}
+
+// Name returns the heading path for messages, such as "Setup > Install",
+// leaving out the document title.
+func (s Section) Name() string {
+	path := s.Path
+	if len(path) > 1 && strings.HasPrefix(s.Key, "#") {
+		path = path[1:]
+	}
+	if len(path) == 0 {
+		return "(preamble)"
+	}
+	return strings.Join(path, " > ")
+}
+
+// Split divides content into sections. Headings inside fenced code do not
+// start sections.
+func Split(content string) []Section {
+	var sections []Section
+	var path markdown.HeadingPath
+	seen := make(map[string]int)
+
+	titled := false
+	add := func(titles []string, text string) {
+		keyed := titles
+		if titled && len(titles) > 0 {
+			keyed = append([]string{"#"}, titles[1:]...)
+		}
+		key := strings.Join(keyed, "\x00")
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key += fmt.Sprintf("\x01%d", n)
+		}
+		sections = append(sections, Section{Path: titles, Key: key, Text: text})
+	}
+
+	start := 0
+	var titles []string
+	for _, block := range markdown.Blocks(content) {
+		if block.Kind != markdown.Heading {
+			continue
+		}
+		if block.Start > start {
+			add(titles, content[start:block.Start])
+		}
+		path.Enter(block.Level, block.Title)
+		titles = path.Titles()
+		if block.Level == 1 {
+			titled = true
+		} else if len(titles) == 1 {
+			titled = false
+		}
+		start = block.Start
+	}
+	if start < len(content) {
+		add(titles, content[start:])
+	}
+	return sections
+}
+
+// Result is the outcome of a merge.
+type Result struct {
+	Content string
+	// Added lists the new template sections inserted into the file.
+	Added []string
+	// Updated lists sections the user had not edited that now follow the
+	// new template.
+	Updated []string
+	// Removed lists unedited sections the new template dropped.
+	Removed []string
+	// Conflicts lists sections that both the user and the template
+	// changed, with their versions.
+	Conflicts []Conflict
+	// Unresolved is set when the file still holds conflict markers from an
+	// earlier merge and was not merged.
+	Unresolved bool
+}
+
+// Conflict is a section changed both in the file and in the template.
+type Conflict struct {
+	Name     string
+	Base     string
+	Yours    string
+	Template string
+}
+
+// Changed reports whether the merge changes anything besides conflicts.
+func (r Result) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// Merge merges the changes between base and template into yours, section
+// by section. An empty base means the template the file was generated from
+// is unknown: only sections missing from the file are added. Conflicting
+// sections get conflict markers when markers is set and otherwise keep
+// the user's version; both are reported in Conflicts. A file that still
+// holds conflict markers is left as it is and reported as Unresolved.
+func Merge(base, yours, template string, markers bool) Result {
+	// The markers split sections apart, so merging again would nest them
+	if HasConflictMarkers(yours) {
+		return Result{Content: yours, Unresolved: true}
+	}
+
+	known := base != ""
+	baseSections := index(Split(base))
+	templateList := Split(template)
+	templateSections := index(templateList)
+
+	var result Result
+	var out []Section
+	for _, section := range Split(yours) {
+		b, inBase := baseSections[section.Key]
+		t, inTemplate := templateSections[section.Key]
+		switch {
+		case !inTemplate:
+			if inBase && same(section.Text, b.Text) {
+				result.Removed = append(result.Removed, section.Name())
+				continue
+			}
+		case same(section.Text, t.Text):
+		case !known:
+			// Without a base the user's version is all we can trust
+		case inBase && same(section.Text, b.Text):
+			section.Text = t.Text
+			result.Updated = append(result.Updated, section.Name())
+		case inBase && same(t.Text, b.Text):
+		default:
+			result.Conflicts = append(result.Conflicts, Conflict{
+				Name:     section.Name(),
+				Base:     b.Text,
+				Yours:    section.Text,
+				Template: t.Text,
+			})
+			if markers {
+				section.Text = MarkerYours + "\n" + trim(section.Text) + "\n" + MarkerSplit + "\n" + trim(t.Text) + "\n" + MarkerTemplate + "\n\n"
+			}
+		}
+		out = append(out, section)
+	}
+
+	// New template sections go after the section that precedes them in the
+	// template, past that section's subsections
+	present := index(out)
+	for i, section := range templateList {
+		if _, ok := present[section.Key]; ok {
+			continue
+		}
+		if _, inBase := baseSections[section.Key]; inBase {
+			continue // deleted by the user
+		}
+		at := 0
+		for j := i - 1; j >= 0; j-- {
+			if k := position(out, templateList[j]); k >= 0 {
+				at = k + 1
+				for at < len(out) && within(out[at], templateList[j]) {
+					at++
+				}
+				break
+			}
+		}
+		out = append(out[:at], append([]Section{section}, out[at:]...)...)
+		present[section.Key] = section
+		result.Added = append(result.Added, section.Name())
+	}
+
+	result.Content = join(out)
+	return result
+}
+
+func index(sections []Section) map[string]Section {
+	m := make(map[string]Section, len(sections))
+	for _, section := range sections {
+		m[section.Key] = section
+	}
+	return m
+}
+
+func position(sections []Section, target Section) int {
+	for i, section := range sections {
+		if section.Key == target.Key {
+			return i
+		}
+	}
+	return -1
+}
+
+// within reports whether section is nested below parent.
+func within(section, parent Section) bool {
+	return strings.HasPrefix(section.Key, parent.Key+"\x00")
+}
+
+func trim(text string) string {
+	return strings.TrimRight(text, " \t\r\n")
+}
+
+// same compares sections ignoring trailing whitespace on lines and blank
+// lines at the end.
+func same(a, b string) bool {
+	return normalize(a) == normalize(b)
+}
+
+func normalize(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// join concatenates sections, separating each from the next heading by a
+// blank line. The last section ends with a single newline.
+func join(sections []Section) string {
+	var b strings.Builder
+	for i, section := range sections {
+		text := section.Text
+		if i == len(sections)-1 && strings.HasSuffix(text, "\n") {
+			text = strings.TrimRight(text, "\n") + "\n"
+		}
+		if i < len(sections)-1 {
+			if !strings.HasSuffix(text, "\n") {
+				text += "\n"
+			}
+			if !strings.HasSuffix(text, "\n\n") && text != "\n" {
+				text += "\n"
+			}
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
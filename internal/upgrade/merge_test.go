@@ -0,0 +1,183 @@
+package upgrade
+
+import (
+	"strings"
+	"testing"
+)
+
+const base = `# Project
+
+## Setup
+
+Run make.
+
+## Usage
+
+Call the tool.
+
+## Notes
+
+Nothing yet.
+`
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		yours    string
+		template string
+		markers  bool
+
+		want          string
+		wantAdded     []string
+		wantUpdated   []string
+		wantRemoved   []string
+		wantConflicts []string
+	}{
+		{
+			name:     "unchanged",
+			yours:    base,
+			template: base,
+			want:     base,
+		},
+		{
+			name:     "clean merge",
+			yours:    strings.Replace(base, "Call the tool.", "Call the tool with --verbose.", 1),
+			template: strings.Replace(base, "Run make.", "Run make install.", 1) + "\n## Testing\n\nRun go test.\n",
+			want: strings.Replace(strings.Replace(base, "Call the tool.", "Call the tool with --verbose.", 1),
+				"Run make.", "Run make install.", 1) + "\n## Testing\n\nRun go test.\n",
+			wantAdded:   []string{"Testing"},
+			wantUpdated: []string{"Setup"},
+		},
+		{
+			name:     "renamed project title",
+			yours:    strings.Replace(base, "# Project", "# My Service", 1),
+			template: strings.Replace(base, "Nothing yet.", "Add notes here.", 1),
+			want: strings.Replace(strings.Replace(base, "# Project", "# My Service", 1),
+				"Nothing yet.", "Add notes here.", 1),
+			wantUpdated: []string{"Notes"},
+		},
+		{
+			name:          "both edited the same section, report",
+			yours:         strings.Replace(base, "Run make.", "Run ./build.sh.", 1),
+			template:      strings.Replace(base, "Run make.", "Run make install.", 1),
+			want:          strings.Replace(base, "Run make.", "Run ./build.sh.", 1),
+			wantConflicts: []string{"Setup"},
+		},
+		{
+			name:     "both edited the same section, markers",
+			yours:    strings.Replace(base, "Run make.", "Run ./build.sh.", 1),
+			template: strings.Replace(base, "Run make.", "Run make install.", 1),
+			markers:  true,
+			want: strings.Replace(base, "## Setup\n\nRun make.\n",
+				"<<<<<<< yours\n## Setup\n\nRun ./build.sh.\n=======\n## Setup\n\nRun make install.\n>>>>>>> template\n", 1),
+			wantConflicts: []string{"Setup"},
+		},
+		{
+			name:     "section deleted locally stays deleted",
+			yours:    strings.Replace(base, "## Usage\n\nCall the tool.\n\n", "", 1),
+			template: strings.Replace(base, "Call the tool.", "Call the tool twice.", 1),
+			want:     strings.Replace(base, "## Usage\n\nCall the tool.\n\n", "", 1),
+		},
+		{
+			name:        "unedited section dropped by the template",
+			yours:       base,
+			template:    strings.Replace(base, "\n## Notes\n\nNothing yet.\n", "", 1),
+			want:        strings.Replace(base, "\n## Notes\n\nNothing yet.\n", "", 1),
+			wantRemoved: []string{"Notes"},
+		},
+		{
+			name:     "edited section dropped by the template is kept",
+			yours:    strings.Replace(base, "Nothing yet.", "Deploy on Fridays.", 1),
+			template: strings.Replace(base, "\n## Notes\n\nNothing yet.\n", "", 1),
+			want:     strings.Replace(base, "Nothing yet.", "Deploy on Fridays.", 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Merge(base, tt.yours, tt.template, tt.markers)
+
+			if result.Content != tt.want {
+				t.Errorf("content =\n%s\nwant\n%s", result.Content, tt.want)
+			}
+			check := func(what string, got, want []string) {
+				if strings.Join(got, ",") != strings.Join(want, ",") {
+					t.Errorf("%s = %v, want %v", what, got, want)
+				}
+			}
+			check("added", result.Added, tt.wantAdded)
+			check("updated", result.Updated, tt.wantUpdated)
+			check("removed", result.Removed, tt.wantRemoved)
+			var conflicts []string
+			for _, conflict := range result.Conflicts {
+				conflicts = append(conflicts, conflict.Name)
+			}
+			check("conflicts", conflicts, tt.wantConflicts)
+		})
+	}
+}
+
+func TestMergeWithoutBase(t *testing.T) {
+	yours := "# Project\n\n## Setup\n\nMy own setup.\n"
+	result := Merge("", yours, base, true)
+
+	if len(result.Conflicts) != 0 || len(result.Updated) != 0 {
+		t.Errorf("merge without a base changed existing sections: %+v", result)
+	}
+	if !strings.Contains(result.Content, "My own setup.") {
+		t.Errorf("the user's section was replaced:\n%s", result.Content)
+	}
+	if strings.Join(result.Added, ",") != "Usage,Notes" {
+		t.Errorf("added = %v, want [Usage Notes]", result.Added)
+	}
+}
+
+func TestMergeTwiceDoesNotNestMarkers(t *testing.T) {
+	yours := strings.Replace(base, "Run make.", "Run make all.", 1)
+	template := strings.Replace(base, "Run make.", "Run make build.", 1)
+
+	first := Merge(base, yours, template, true)
+	if !HasConflictMarkers(first.Content) {
+		t.Fatalf("first merge has no conflict markers:\n%s", first.Content)
+	}
+
+	// The lock keeps the old base of conflicting files
+	second := Merge(base, first.Content, template, true)
+	if second.Content != first.Content {
+		t.Errorf("second merge changed the file:\n%s", second.Content)
+	}
+	if strings.Count(second.Content, MarkerYours) != 1 {
+		t.Errorf("markers were nested:\n%s", second.Content)
+	}
+	if !second.Unresolved || second.Changed() {
+		t.Errorf("second merge = %+v, want an unresolved, unchanged file", second)
+	}
+}
+
+func TestHasConflictMarkers(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"# Doc\n\n" + MarkerYours + "\nmine\n" + MarkerSplit + "\ntheirs\n" + MarkerTemplate + "\n", true},
+		{"# Doc\n\n" + MarkerTemplate + "  \r\n", true},
+		{"# Doc\n\n=======\n", false},
+		{"# Doc\n\nSee `" + MarkerYours + "` markers.\n", false},
+	}
+	for _, tt := range tests {
+		if got := HasConflictMarkers(tt.content); got != tt.want {
+			t.Errorf("HasConflictMarkers(%q) = %t, want %t", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestSplitIgnoresFencedHeadings(t *testing.T) {
+	sections := Split("# Title\n\n## Code\n\n```sh\n# not a heading\n```\n\n## Next\n")
+
+	var names []string
+	for _, section := range sections {
+		names = append(names, section.Name())
+	}
+	if got := strings.Join(names, ","); got != "Title,Code,Next" {
+		t.Errorf("sections = %s", got)
+	}
+}
@@ -0,0 +1,316 @@
+// Package watcher reports batches of file changes, using filesystem
+// notifications where the platform supports them and polling otherwise.
+package watcher
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+type Op string
+
+const (
+	Created  Op = "created"
+	Modified Op = "modified"
+	Removed  Op = "removed"
+)
+
+type Change struct {
+	Path string
+	Op   Op
+}
+
+type Watcher struct {
+	Paths        []string
+	Recursive    bool
+	Debounce     time.Duration
+	PollInterval time.Duration
+	Poll         bool
+	// Filter reports whether a changed path is of interest. A nil Filter
+	// accepts every path.
+	Filter func(path string) bool
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+func New(paths ...string) *Watcher {
+	return &Watcher{
+		Paths:        paths,
+		Debounce:     300 * time.Millisecond,
+		PollInterval: time.Second,
+	}
+}
+
+// Run calls fn with each debounced batch of changes until ctx is cancelled.
+// Filesystem notifications are used unless Poll is set or they cannot be
+// set up, in which case the watched paths are polled every PollInterval.
+func (w *Watcher) Run(ctx context.Context, fn func([]Change)) error {
+	events := make(chan Change)
+
+	if w.Poll {
+		go w.poll(ctx, events)
+	} else {
+		notifier, err := w.newNotifier()
+		if err != nil {
+			go w.poll(ctx, events)
+		} else {
+			defer notifier.Close()
+			go w.notify(ctx, notifier, events)
+		}
+	}
+
+	pending := make(map[string]Op)
+	timer := time.NewTimer(w.Debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change := <-events:
+			if w.Filter != nil && !w.Filter(change.Path) {
+				continue
+			}
+			pending[change.Path] = mergeOp(pending[change.Path], change.Op)
+			// Reset needs a stopped, drained timer, or a stale expiry
+			// would flush the batch before the debounce ends
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(w.Debounce)
+		case <-timer.C:
+			if len(pending) == 0 {
+				continue
+			}
+			fn(flush(pending))
+			pending = make(map[string]Op)
+		}
+	}
+}
+
+// mergeOp combines two operations on the same path within one batch.
+func mergeOp(previous, next Op) Op {
+	if previous == Created && next == Modified {
+		return Created
+	}
+	return next
+}
+
+func flush(pending map[string]Op) []Change {
+	changes := make([]Change, 0, len(pending))
+	for path, op := range pending {
+		changes = append(changes, Change{Path: path, Op: op})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func (w *Watcher) newNotifier() (*fsnotify.Watcher, error) {
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dir := range w.directories() {
+		if err := notifier.Add(dir); err != nil {
+			notifier.Close()
+			return nil, err
+		}
+	}
+
+	return notifier, nil
+}
+
+// directories returns the directories that must be watched to observe
+// every path. Files are watched through their parent directory so that
+// editors which replace files on save are handled.
+func (w *Watcher) directories() []string {
+	seen := make(map[string]bool)
+	var dirs []string
+
+	add := func(dir string) {
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, path := range w.Paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			add(filepath.Dir(path))
+			continue
+		}
+
+		if !w.Recursive {
+			add(path)
+			continue
+		}
+
+		filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if p != path && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			add(p)
+			return nil
+		})
+	}
+
+	return dirs
+}
+
+func (w *Watcher) notify(ctx context.Context, notifier *fsnotify.Watcher, events chan<- Change) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notifier.Errors:
+			// Errors such as event queue overflows are not fatal; the next
+			// event still triggers a full re-run of the affected work.
+		case event, ok := <-notifier.Events:
+			if !ok {
+				return
+			}
+
+			var op Op
+			switch {
+			case event.Has(fsnotify.Create):
+				op = Created
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if w.Recursive {
+						notifier.Add(event.Name)
+					}
+					continue
+				}
+			case event.Has(fsnotify.Write):
+				op = Modified
+			case event.Has(fsnotify.Remove), event.Has(fsnotify.Rename):
+				op = Removed
+			default:
+				continue
+			}
+
+			if !w.watches(event.Name) {
+				continue
+			}
+
+			select {
+			case events <- Change{Path: event.Name, Op: op}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+func (w *Watcher) poll(ctx context.Context, events chan<- Change) {
+	previous := w.snapshot()
+
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := w.snapshot()
+		var changes []Change
+
+		for path, state := range current {
+			old, ok := previous[path]
+			switch {
+			case !ok:
+				changes = append(changes, Change{Path: path, Op: Created})
+			case old != state:
+				changes = append(changes, Change{Path: path, Op: Modified})
+			}
+		}
+		for path := range previous {
+			if _, ok := current[path]; !ok {
+				changes = append(changes, Change{Path: path, Op: Removed})
+			}
+		}
+		previous = current
+
+		for _, change := range changes {
+			select {
+			case events <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// snapshot records the size and modification time of every watched file.
+func (w *Watcher) snapshot() map[string]fileState {
+	states := make(map[string]fileState)
+
+	record := func(path string, info fs.FileInfo) {
+		states[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+	}
+
+	for _, path := range w.Paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			record(path, info)
+			continue
+		}
+
+		filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if p != path && (!w.Recursive || d.Name() == ".git") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				record(p, info)
+			}
+			return nil
+		})
+	}
+
+	return states
+}
+
+// watches reports whether an event path falls under one of the watched
+// paths, since watching a file's parent directory also reports its siblings.
+func (w *Watcher) watches(path string) bool {
+	for _, watched := range w.Paths {
+		info, err := os.Stat(watched)
+		if err == nil && info.IsDir() {
+			return true
+		}
+		if filepath.Clean(watched) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunBatchesChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		poll bool
+	}{
+		{"notifications", false},
+		{"polling", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			w := New(dir)
+			w.Poll = tt.poll
+			w.PollInterval = 20 * time.Millisecond
+			w.Debounce = 150 * time.Millisecond
+			w.Filter = func(path string) bool { return strings.HasSuffix(path, ".md") }
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			batches := make(chan []Change, 4)
+			done := make(chan error)
+			go func() {
+				done <- w.Run(ctx, func(changes []Change) { batches <- changes })
+			}()
+			// Give the watcher time to take its first snapshot
+			time.Sleep(100 * time.Millisecond)
+
+			// A burst of writes, spread over less than the debounce
+			for _, name := range []string{"a.md", "b.md", "ignored.txt", "c.md"} {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+					t.Fatal(err)
+				}
+				time.Sleep(30 * time.Millisecond)
+			}
+
+			select {
+			case changes := <-batches:
+				var names []string
+				for _, change := range changes {
+					names = append(names, filepath.Base(change.Path)+" "+string(change.Op))
+				}
+				if got := strings.Join(names, ", "); got != "a.md created, b.md created, c.md created" {
+					t.Errorf("batch = %s", got)
+				}
+			case <-ctx.Done():
+				t.Fatal("no batch reported")
+			}
+
+			// Nothing else is pending
+			select {
+			case changes := <-batches:
+				t.Errorf("unexpected second batch %v", changes)
+			case <-time.After(3 * w.Debounce):
+			}
+
+			cancel()
+			if err := <-done; err != nil {
+				t.Errorf("Run = %v", err)
+			}
+		})
+	}
+}
+
+func TestMergeOp(t *testing.T) {
+	tests := []struct {
+		previous, next, want Op
+	}{
+		{"", Created, Created},
+		{Created, Modified, Created},
+		{Modified, Modified, Modified},
+		{Created, Removed, Removed},
+		{Removed, Created, Created},
+	}
+	for _, tt := range tests {
+		if got := mergeOp(tt.previous, tt.next); got != tt.want {
+			t.Errorf("mergeOp(%q, %q) = %q, want %q", tt.previous, tt.next, got, tt.want)
+		}
+	}
+}
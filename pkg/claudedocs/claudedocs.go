@@ -0,0 +1,488 @@
+// Package claudedocs splits and merges Markdown documentation for use as
+// Claude Code context. It is the library behind the claude-docs CLI:
+// functions return their results and report progress through events
+// instead of printing.
+package claudedocs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/claude-code/claude-doc-structure/internal/events"
+	"github.com/claude-code/claude-doc-structure/internal/merger"
+	"github.com/claude-code/claude-doc-structure/internal/output"
+	"github.com/claude-code/claude-doc-structure/internal/splitter"
+)
+
+// Event is a structured progress event.
+type Event = events.Event
+
+// EventKind identifies the kind of an Event.
+type EventKind = events.Kind
+
+const (
+	FileWritten    = events.FileWritten
+	FileUnchanged  = events.FileUnchanged
+	FileRemoved    = events.FileRemoved
+	FileConflict   = events.FileConflict
+	FileKept       = events.FileKept
+	SplitFinished  = events.SplitFinished
+	DocumentMerged = events.DocumentMerged
+	MergeFinished  = events.MergeFinished
+)
+
+// WriteOptions control how SplitFile, SplitFiles and MergeFile write files.
+type WriteOptions struct {
+	// DryRun reports the planned changes to Log instead of writing them.
+	DryRun bool
+	// Force allows existing files to be overwritten.
+	Force bool
+	// Log receives the planned changes of a dry run; nil discards them.
+	Log io.Writer
+}
+
+func (w WriteOptions) writer() *output.Writer {
+	out := &output.Writer{DryRun: w.DryRun, Force: w.Force, Log: w.Log}
+	if out.Log == nil {
+		out.Log = io.Discard
+	}
+	return out
+}
+
+type SplitMethod int
+
+const (
+	ByHeaders SplitMethod = iota
+	ByLines
+	BySize
+	BySemantic
+)
+
+type SplitOptions struct {
+	Method       SplitMethod
+	HeaderLevel  int
+	MaxSections  int
+	LinesPerFile int
+	MaxSizeKB    int64
+	// ChunkTokens and OverlapTokens control BySemantic chunking.
+	ChunkTokens   int
+	OverlapTokens int
+	// Prefix is prepended to every generated section filename.
+	Prefix string
+	// FilenameTemplate names sections using {prefix}, {slug}, {n} and {nn}.
+	FilenameTemplate string
+}
+
+const (
+	DefaultFilenameTemplate  = splitter.DefaultFilenameTemplate
+	NumberedFilenameTemplate = splitter.NumberedFilenameTemplate
+)
+
+// DefaultSplitOptions returns the options used by the CLI by default.
+func DefaultSplitOptions() SplitOptions {
+	return SplitOptions{
+		Method:           ByHeaders,
+		HeaderLevel:      2,
+		MaxSections:      10,
+		LinesPerFile:     200,
+		MaxSizeKB:        100,
+		ChunkTokens:      500,
+		OverlapTokens:    50,
+		FilenameTemplate: splitter.DefaultFilenameTemplate,
+	}
+}
+
+type Section struct {
+	Title string
+	// Filename is the suggested filename for the section.
+	Filename string
+	Content  string
+	// HeadingPath lists the headings enclosing the section, outermost first.
+	HeadingPath []string
+	// StartByte and EndByte locate the section in the input, end exclusive;
+	// StartLine and EndLine are 1-based and inclusive.
+	StartByte int
+	EndByte   int
+	StartLine int
+	EndLine   int
+}
+
+// Split reads a Markdown document from r and splits it into sections.
+// Sections carry no navigation links; writing them is left to the caller.
+func Split(ctx context.Context, r io.Reader, opts SplitOptions) ([]Section, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s := newSplitter("", opts)
+	method, err := splitMethod(opts.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := s.Sections(string(content), method)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]Section, len(parts))
+	for i, part := range parts {
+		sections[i] = Section{
+			Title:       part.Title,
+			Filename:    part.Filename,
+			Content:     part.Content,
+			HeadingPath: part.HeadingPath,
+			StartByte:   part.StartByte,
+			EndByte:     part.EndByte,
+			StartLine:   part.StartLine,
+			EndLine:     part.EndLine,
+		}
+	}
+	return sections, nil
+}
+
+// SplitFileOptions control how SplitFile and SplitFiles write sections.
+type SplitFileOptions struct {
+	SplitOptions
+	// OutputDir receives the sections. SplitFile defaults to the input's
+	// directory; SplitFiles splits each input into a directory under it,
+	// or next to the input when it is empty.
+	OutputDir string
+	// AddNavigation links each section to its neighbours.
+	AddNavigation bool
+	// WriteManifest writes index.json and chunks.jsonl; WriteIndex writes
+	// an index.md entry point.
+	WriteManifest bool
+	WriteIndex    bool
+	// Update rewrites only the sections that changed since the split
+	// recorded in the manifest, keeping local edits.
+	Update bool
+	// ThresholdTokens and ThresholdKB select the files SplitFiles splits;
+	// ThresholdTokens takes precedence when set.
+	ThresholdTokens int
+	ThresholdKB     int64
+	OnEvent         func(Event)
+}
+
+// DefaultSplitFileOptions returns the options used by the CLI by default.
+func DefaultSplitFileOptions() SplitFileOptions {
+	return SplitFileOptions{
+		SplitOptions:  DefaultSplitOptions(),
+		AddNavigation: true,
+		ThresholdKB:   100,
+	}
+}
+
+// SplitFile splits the Markdown file at path into section files, using the
+// same sections Split returns for its content.
+func SplitFile(ctx context.Context, path string, opts SplitFileOptions, w WriteOptions) error {
+	s, method, err := newFileSplitter(ctx, path, opts, w)
+	if err != nil {
+		return err
+	}
+	return s.Split(method)
+}
+
+// SplitFiles splits every Markdown file found in paths that exceeds the
+// threshold. paths may be files, directories or glob patterns; one
+// manifest describes the sections of all of them.
+func SplitFiles(ctx context.Context, paths []string, opts SplitFileOptions, w WriteOptions) error {
+	s, method, err := newFileSplitter(ctx, "", opts, w)
+	if err != nil {
+		return err
+	}
+	return s.SplitAll(paths, method)
+}
+
+func newFileSplitter(ctx context.Context, path string, opts SplitFileOptions, w WriteOptions) (*splitter.Splitter, splitter.SplitMethod, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	method, err := splitMethod(opts.Method)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s := newSplitter(path, opts.SplitOptions)
+	s.OutputDir = opts.OutputDir
+	s.AddNavigation = opts.AddNavigation
+	s.WriteManifest = opts.WriteManifest
+	s.WriteIndex = opts.WriteIndex
+	s.Update = opts.Update
+	s.ThresholdTokens = opts.ThresholdTokens
+	s.ThresholdKB = opts.ThresholdKB
+	s.Output = w.writer()
+	s.OnEvent = opts.OnEvent
+	return s, method, nil
+}
+
+// newSplitter returns a splitter for the input file at path that sections
+// documents as opts describe.
+func newSplitter(path string, opts SplitOptions) *splitter.Splitter {
+	s := splitter.New(path, "", opts.Prefix)
+	s.HeaderLevel = opts.HeaderLevel
+	s.MaxSections = opts.MaxSections
+	s.LinesPerFile = opts.LinesPerFile
+	s.MaxSizeKB = opts.MaxSizeKB
+	s.ChunkTokens = opts.ChunkTokens
+	s.OverlapTokens = opts.OverlapTokens
+	s.FilenameTemplate = opts.FilenameTemplate
+	return s
+}
+
+func splitMethod(method SplitMethod) (splitter.SplitMethod, error) {
+	switch method {
+	case ByHeaders:
+		return splitter.ByHeaders, nil
+	case ByLines:
+		return splitter.ByLines, nil
+	case BySize:
+		return splitter.BySize, nil
+	case BySemantic:
+		return splitter.BySemantic, nil
+	default:
+		return 0, fmt.Errorf("unknown split method %d", method)
+	}
+}
+
+// Source is a document to merge. Open is called once, while the document
+// is being written, so sources may be backed by files, network resources
+// or memory.
+type Source struct {
+	// Name is used for headings and the table of contents.
+	Name string
+	// Path is shown as the document's location and used to resolve its
+	// relative links.
+	Path    string
+	Size    int64
+	ModTime time.Time
+	Open    func() (io.ReadCloser, error)
+}
+
+// FileSource returns a Source for the file at path.
+func FileSource(path string) (Source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Source{}, err
+	}
+
+	return Source{
+		Name:    filepath.Base(path),
+		Path:    path,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}, nil
+}
+
+// StringSource returns a Source with the given name and content.
+func StringSource(name, content string) Source {
+	return Source{
+		Name:    name,
+		Path:    name,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+		Open: func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(content)), nil
+		},
+	}
+}
+
+type MergeOptions struct {
+	// SourceDir is reported in the header and used to resolve relative links.
+	SourceDir         string
+	AddTOC            bool
+	AddDividers       bool
+	PreserveStructure bool
+	AddSummary        bool
+	OptimizeForClaude bool
+	// Jobs bounds how many sources are read and processed concurrently.
+	Jobs int
+	// CacheDir, when set, keeps the processed form of each source so that
+	// unchanged sources skip link rewriting on the next merge into the
+	// same output. Entries a merge does not use are removed.
+	CacheDir string
+	OnEvent  func(Event)
+}
+
+// DefaultMergeOptions returns the options used by the CLI by default.
+func DefaultMergeOptions() MergeOptions {
+	return MergeOptions{
+		SourceDir:         ".",
+		AddTOC:            true,
+		AddDividers:       true,
+		PreserveStructure: true,
+		AddSummary:        true,
+		OptimizeForClaude: true,
+		Jobs:              runtime.NumCPU(),
+	}
+}
+
+// Merge writes sources to w as a single document, in the given order.
+func Merge(ctx context.Context, sources []Source, opts MergeOptions, w io.Writer) error {
+	if err := render(ctx, sources, opts, "-", w); err != nil {
+		return err
+	}
+
+	events.Handler(opts.OnEvent).Emit(Event{Kind: MergeFinished, Path: "-", Count: len(sources)})
+	return nil
+}
+
+// MergeFile merges sources into the file at path, which is replaced
+// atomically once the whole document is written. The output of an earlier
+// merge is replaced without Force.
+func MergeFile(ctx context.Context, sources []Source, opts MergeOptions, path string, w WriteOptions) error {
+	out := w.writer()
+	if !out.Force && merger.IsMergedOutput(path) {
+		out.Force = true
+	}
+
+	f, err := out.Create(path, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if err := render(ctx, sources, opts, path, f); err != nil {
+		f.Abort()
+		return err
+	}
+	if err := f.Commit(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	if !out.DryRun {
+		events.Handler(opts.OnEvent).Emit(Event{Kind: MergeFinished, Path: path, Count: len(sources)})
+	}
+	return nil
+}
+
+// Check reports whether the file at path holds what MergeFile would write
+// for sources. Lines that change on every run, such as the generation time
+// and file modification times, are ignored. Check only reads: it neither
+// fills nor prunes the cache.
+func Check(ctx context.Context, sources []Source, opts MergeOptions, path string) (bool, error) {
+	if path == "-" {
+		return false, fmt.Errorf("cannot check output written to stdout")
+	}
+
+	existing, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read output file: %w", err)
+	}
+	defer existing.Close()
+
+	opts.CacheDir = ""
+	opts.OnEvent = nil
+
+	// Stream the expected output through a pipe so that neither side is
+	// held in memory in full.
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pw.CloseWithError(render(ctx, sources, opts, path, pw))
+	}()
+
+	equal, err := merger.EqualIgnoringVolatileLines(existing, pr)
+	pr.Close()
+	<-done
+
+	return equal, err
+}
+
+// render writes sources to w. outputFile names the destination, so that
+// merges into different outputs keep separate cache entries.
+func render(ctx context.Context, sources []Source, opts MergeOptions, outputFile string, w io.Writer) error {
+	m := merger.New(opts.SourceDir, outputFile)
+	m.AddTOC = opts.AddTOC
+	m.AddDividers = opts.AddDividers
+	m.PreserveStructure = opts.PreserveStructure
+	m.AddSummary = opts.AddSummary
+	m.OptimizeForClaude = opts.OptimizeForClaude
+	m.Jobs = opts.Jobs
+	m.CacheDir = opts.CacheDir
+	m.OnEvent = opts.OnEvent
+
+	documents := make([]merger.Document, len(sources))
+	for i, source := range sources {
+		if source.Open == nil {
+			return fmt.Errorf("source %s has no Open function", source.Name)
+		}
+		documents[i] = merger.Document{
+			Filename: source.Name,
+			Path:     source.Path,
+			Size:     source.Size,
+			ModTime:  source.ModTime,
+			Open:     source.Open,
+		}
+	}
+
+	return m.RenderDocuments(ctx, w, documents)
+}
+
+// DirOptions select the documents DirSources reads from a directory.
+type DirOptions struct {
+	// Pattern matches the filenames of the documents.
+	Pattern string
+	// Exclude lists filename patterns to leave out.
+	Exclude   []string
+	Recursive bool
+	// Skip lists files and directories that are never read, such as the
+	// merge output and the cache directory.
+	Skip []string
+}
+
+// DefaultDirOptions returns the options used by the CLI by default.
+func DefaultDirOptions() DirOptions {
+	return DirOptions{Pattern: "*.md"}
+}
+
+// DirSources returns the documents in dir selected by opts, sorted by
+// filename. It fails when no document matches.
+func DirSources(dir string, opts DirOptions) ([]Source, error) {
+	documents, err := dirMerger(dir, opts).Documents()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]Source, len(documents))
+	for i, doc := range documents {
+		sources[i] = Source{
+			Name:    doc.Filename,
+			Path:    doc.Path,
+			Size:    doc.Size,
+			ModTime: doc.ModTime,
+			Open:    doc.Open,
+		}
+	}
+	return sources, nil
+}
+
+// MatchesDir reports whether path is one of the documents DirSources would
+// return for dir, for filtering file change notifications.
+func MatchesDir(dir string, opts DirOptions, path string) bool {
+	return dirMerger(dir, opts).Matches(path)
+}
+
+func dirMerger(dir string, opts DirOptions) *merger.Merger {
+	m := merger.New(dir, "")
+	m.Pattern = opts.Pattern
+	m.Exclude = opts.Exclude
+	m.Recursive = opts.Recursive
+	m.Skip = opts.Skip
+	return m
+}
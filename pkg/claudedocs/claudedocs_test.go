@@ -0,0 +1,335 @@
+package claudedocs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// documentOrder returns the document names in the order Merge wrote them.
+func documentOrder(merged string) []string {
+	var names []string
+	for _, line := range strings.Split(merged, "\n") {
+		if name, ok := strings.CutPrefix(line, "## Document: "); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func TestMergeKeepsSourceOrder(t *testing.T) {
+	names := []string{"zeta.md", "alpha.md", "mid.md", "beta.md", "omega.md"}
+
+	for _, jobs := range []int{1, 2, 8} {
+		var sources []Source
+		for _, name := range names {
+			sources = append(sources, StringSource(name, "# "+name+"\n\nbody of "+name+"\n"))
+		}
+
+		opts := DefaultMergeOptions()
+		opts.Jobs = jobs
+		var buf bytes.Buffer
+		if err := Merge(context.Background(), sources, opts, &buf); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := documentOrder(buf.String()); strings.Join(got, ",") != strings.Join(names, ",") {
+			t.Errorf("jobs=%d: documents written in order %v, want %v", jobs, got, names)
+		}
+	}
+}
+
+func TestMergeEvents(t *testing.T) {
+	sources := []Source{
+		StringSource("b.md", "# B\n"),
+		StringSource("a.md", "# A\n"),
+	}
+
+	var got []string
+	opts := DefaultMergeOptions()
+	opts.OnEvent = func(e Event) {
+		switch e.Kind {
+		case DocumentMerged:
+			got = append(got, "merged "+e.Path)
+		case MergeFinished:
+			got = append(got, "finished "+e.Path+" "+strings.Repeat("+", e.Count))
+		}
+	}
+	if err := Merge(context.Background(), sources, opts, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"merged b.md", "merged a.md", "finished - ++"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	// Merge works without a callback
+	opts.OnEvent = nil
+	if err := Merge(context.Background(), sources, opts, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStringSource(t *testing.T) {
+	source := StringSource("notes.md", "# Notes\n\nSee [guide](guide.md).\n")
+
+	if source.Name != "notes.md" || source.Path != "notes.md" || source.Size != int64(len("# Notes\n\nSee [guide](guide.md).\n")) {
+		t.Errorf("source = %s %s %d", source.Name, source.Path, source.Size)
+	}
+
+	// Each Open reads the content from the start
+	for i := 0; i < 2; i++ {
+		r, err := source.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "# Notes\n\nSee [guide](guide.md).\n" {
+			t.Errorf("open %d read %q", i, data)
+		}
+	}
+
+	opts := DefaultMergeOptions()
+	opts.SourceDir = "docs"
+	var buf bytes.Buffer
+	if err := Merge(context.Background(), []Source{source}, opts, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "See [guide](docs/guide.md).") {
+		t.Errorf("relative link was not resolved against SourceDir:\n%s", buf.String())
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	failing := Source{
+		Name: "broken.md",
+		Path: "broken.md",
+		Open: func() (io.ReadCloser, error) {
+			return nil, errors.New("unreachable")
+		},
+	}
+
+	tests := []struct {
+		name    string
+		sources []Source
+		want    string
+	}{
+		{"no sources", nil, "no documents"},
+		{"missing Open", []Source{{Name: "empty.md"}}, "has no Open function"},
+		{"failing Open", []Source{StringSource("ok.md", "# OK\n"), failing}, "unreachable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Merge(context.Background(), tt.sources, DefaultMergeOptions(), io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDirSources(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"c.md":       "# C\n",
+		"a.md":       "# A\n",
+		"b.draft.md": "# Draft\n",
+		"notes.txt":  "text\n",
+		"merged.md":  "# Merged Documentation\n",
+	})
+	output := filepath.Join(dir, "merged.md")
+
+	opts := DefaultDirOptions()
+	opts.Exclude = []string{"*.draft.md"}
+	opts.Skip = []string{output}
+	sources, err := DirSources(dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, source := range sources {
+		names = append(names, source.Name)
+	}
+	if strings.Join(names, ",") != "a.md,c.md" {
+		t.Errorf("sources = %v, want a.md,c.md", names)
+	}
+
+	for path, want := range map[string]bool{
+		filepath.Join(dir, "a.md"):        true,
+		filepath.Join(dir, "new.md"):      true,
+		filepath.Join(dir, "b.draft.md"):  false,
+		filepath.Join(dir, "notes.txt"):   false,
+		output:                            false,
+		filepath.Join(dir, "sub", "x.md"): false,
+	} {
+		if got := MatchesDir(dir, opts, path); got != want {
+			t.Errorf("MatchesDir(%s) = %t, want %t", path, got, want)
+		}
+	}
+
+	if _, err := DirSources(t.TempDir(), opts); err == nil {
+		t.Error("DirSources of an empty directory succeeded")
+	}
+}
+
+func TestMergeFileAndCheck(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.md": "# A\n\nalpha\n",
+		"b.md": "# B\n\nbeta\n",
+	})
+	output := filepath.Join(t.TempDir(), "merged.md")
+	cacheDir := t.TempDir()
+
+	opts := DefaultMergeOptions()
+	opts.SourceDir = dir
+	opts.CacheDir = cacheDir
+	sources := func() []Source {
+		t.Helper()
+
+		sources, err := DirSources(dir, DefaultDirOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sources
+	}
+	check := func() bool {
+		t.Helper()
+
+		upToDate, err := Check(context.Background(), sources(), opts, output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return upToDate
+	}
+
+	if check() {
+		t.Error("missing output is reported up to date")
+	}
+	if err := MergeFile(context.Background(), sources(), opts, output, WriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !check() {
+		t.Error("fresh output is reported stale")
+	}
+
+	// Regenerating an earlier merge output does not need Force
+	if err := MergeFile(context.Background(), sources(), opts, output, WriteOptions{}); err != nil {
+		t.Errorf("remerge without Force: %v", err)
+	}
+
+	// Check only reads: the cache holds what the merge put there
+	entries := func() []string {
+		var names []string
+		filepath.Walk(cacheDir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				names = append(names, path)
+			}
+			return nil
+		})
+		sort.Strings(names)
+		return names
+	}
+	before := entries()
+	if len(before) != 2 {
+		t.Fatalf("cache holds %d entries after merging 2 documents", len(before))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("# A\n\nchanged\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if check() {
+		t.Error("output is reported up to date after an input changed")
+	}
+	if after := entries(); strings.Join(after, ",") != strings.Join(before, ",") {
+		t.Errorf("check changed the cache from %v to %v", before, after)
+	}
+
+	if _, err := Check(context.Background(), sources(), opts, "-"); err == nil {
+		t.Error("checking stdout output succeeded")
+	}
+}
+
+func TestMergeFileRefusesOtherFiles(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(output, []byte("# My notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sources := []Source{StringSource("a.md", "# A\n")}
+
+	if err := MergeFile(context.Background(), sources, DefaultMergeOptions(), output, WriteOptions{}); err == nil {
+		t.Error("MergeFile overwrote a file that is not a merge output")
+	}
+	if err := MergeFile(context.Background(), sources, DefaultMergeOptions(), output, WriteOptions{DryRun: true, Force: true}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(output); string(data) != "# My notes\n" {
+		t.Errorf("dry run changed the file to %q", data)
+	}
+	if err := MergeFile(context.Background(), sources, DefaultMergeOptions(), output, WriteOptions{Force: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitFileMatchesSplit(t *testing.T) {
+	const content = "# Guide\n\n## Install\n\nRun make.\n\n## Usage\n\nRun it.\n"
+	dir := writeFiles(t, map[string]string{"guide.md": content})
+
+	opts := DefaultSplitFileOptions()
+	opts.OutputDir = filepath.Join(dir, "out")
+	opts.AddNavigation = false
+	var written []string
+	opts.OnEvent = func(e Event) {
+		if e.Kind == FileWritten {
+			written = append(written, filepath.Base(e.Path))
+		}
+	}
+	if err := SplitFile(context.Background(), filepath.Join(dir, "guide.md"), opts, WriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	sections, err := Split(context.Background(), strings.NewReader(content), opts.SplitOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, section := range sections {
+		want = append(want, section.Filename)
+		data, err := os.ReadFile(filepath.Join(opts.OutputDir, section.Filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != section.Content {
+			t.Errorf("%s = %q, Split returned %q", section.Filename, data, section.Content)
+		}
+	}
+	if strings.Join(written, ",") != strings.Join(want, ",") {
+		t.Errorf("written files = %v, want %v", written, want)
+	}
+
+	opts.Method = SplitMethod(99)
+	if err := SplitFile(context.Background(), filepath.Join(dir, "guide.md"), opts, WriteOptions{}); err == nil {
+		t.Error("unknown split method was accepted")
+	}
+}